@@ -1,11 +1,29 @@
 package jsonschematics
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/ashbeelghouri/jsonschematics/api/parsers"
+	apiv0 "github.com/ashbeelghouri/jsonschematics/api/v0"
+	apiv1 "github.com/ashbeelghouri/jsonschematics/api/v1"
+	apiv2 "github.com/ashbeelghouri/jsonschematics/api/v2"
+	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
 	v2 "github.com/ashbeelghouri/jsonschematics/data/v2"
+	"github.com/ashbeelghouri/jsonschematics/errorHandler"
+	"github.com/ashbeelghouri/jsonschematics/registry"
 	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 )
 
 func TestV2Validate(t *testing.T) {
@@ -32,3 +50,1860 @@ func NewFun(i interface{}, attr map[string]interface{}) error {
 	log.Println(attr)
 	return nil
 }
+
+func TestBasicValidatorNames(t *testing.T) {
+	var v validators.Validators
+	v.BasicValidators()
+	if err := v.ValidationFns["IsURL"]("https://example.com/users", nil); err != nil {
+		t.Errorf("IsURL should accept urls, got %v", err)
+	}
+	if err := v.ValidationFns["IsURL"]("0b5e4a5e-0f8e-4b8e-9e5e-1b5e4a5e0f8e", nil); err == nil {
+		t.Error("IsURL should not accept uuids")
+	}
+	if fn, exists := v.ValidationFns["IsValidUuid"]; !exists {
+		t.Error("IsValidUuid should be registered")
+	} else if err := fn("0b5e4a5e-0f8e-4b8e-9e5e-1b5e4a5e0f8e", nil); err != nil {
+		t.Errorf("IsValidUuid should accept uuids, got %v", err)
+	}
+}
+
+func TestV0Compile(t *testing.T) {
+	var schematics v0.Schematics
+	err := schematics.LoadJsonSchemaFile("test-data/schema/direct/v0/compile-errors.json")
+	if err != nil {
+		t.Error(err)
+	}
+	errs := schematics.Compile()
+	if !errs.HasErrors() {
+		t.Fatal("expected schema errors")
+	}
+	expected := map[string]bool{
+		"user..email [target_key]":                               false,
+		"user..email [depends_on.0]":                             false,
+		"user..email [validators.MatchRegex.attributes.regex]":   false,
+		"user.name [validators.IsStrnig]":                        false,
+		"user.name [validators.MaxLengthAllowed.attributes.max]": false,
+		"user.name [validators.MinLengthAllowed.attributes.min]": false,
+		"user.name [operators.Add]":                              false,
+		"user.name [operators.Add.attributes.add_with]":          false,
+		"user.tags.*x [target_key]":                              false,
+	}
+	for _, e := range errs.Errors {
+		key := e.Target + " [" + e.Path + "]"
+		if _, ok := expected[key]; !ok {
+			t.Errorf("unexpected schema error: %s", key)
+		}
+		expected[key] = true
+	}
+	for key, found := range expected {
+		if !found {
+			t.Errorf("schema error not reported: %s", key)
+		}
+	}
+}
+
+func TestV0ExportJsonSchema(t *testing.T) {
+	var schematics v0.Schematics
+	err := schematics.LoadJsonSchemaFile("test-data/schema/direct/v0/example-2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := schematics.ToJsonSchema()
+	user := doc["properties"].(map[string]interface{})["user"].(map[string]interface{})
+	properties := user["properties"].(map[string]interface{})
+	if required := user["required"].([]string); len(required) != 2 {
+		t.Errorf("expected name and email to be required, got %v", required)
+	}
+	name := properties["name"].(map[string]interface{})
+	if name["maxLength"] != float64(50) || name["title"] != "Name" {
+		t.Errorf("unexpected schema for name: %v", name)
+	}
+	email := properties["email"].(map[string]interface{})
+	if email["format"] != "email" {
+		t.Errorf("email format not exported: %v", email)
+	}
+	if _, ok := email[v0.ExtensionValidators].(map[string]v0.Constant)["IsEmail"]; !ok {
+		t.Errorf("custom error of IsEmail should be kept in the extensions: %v", email)
+	}
+	website := properties["website"].(map[string]interface{})
+	if _, ok := website[v0.ExtensionValidators].(map[string]v0.Constant)["HaveURLHostName"]; !ok {
+		t.Errorf("HaveURLHostName should be exported as an extension: %v", website)
+	}
+	city := properties["addresses"].(map[string]interface{})["items"].(map[string]interface{})
+	if city["required"].([]string)[0] != "city" {
+		t.Errorf("city should be required inside the array items: %v", city)
+	}
+
+	var overlapping v0.Schematics
+	err = overlapping.LoadMap(map[string]interface{}{
+		"version": "1",
+		"fields": map[string]interface{}{
+			"name": map[string]interface{}{"validators": map[string]interface{}{
+				"MaxLengthAllowed":       map[string]interface{}{"attributes": map[string]interface{}{"max": 10}},
+				"InBetweenLengthAllowed": map[string]interface{}{"attributes": map[string]interface{}{"min": 2, "max": 20}},
+			}},
+			"born": map[string]interface{}{"type": "date", "validators": map[string]interface{}{"IsValidDate": map[string]interface{}{}}},
+			"tags": map[string]interface{}{"type": "array", "validators": map[string]interface{}{
+				"ArrayLengthMin": map[string]interface{}{"attributes": map[string]interface{}{"min": 3}},
+			}},
+			"tags.*":   map[string]interface{}{"required": true, "validators": map[string]interface{}{"IsString": map[string]interface{}{}}},
+			"labels":   map[string]interface{}{"type": "array", "validators": map[string]interface{}{}},
+			"labels.*": map[string]interface{}{"required": true, "validators": map[string]interface{}{"IsString": map[string]interface{}{}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := overlapping.ExportJsonSchema()
+	for i := 0; i < 20; i++ {
+		if exported, _ := overlapping.ExportJsonSchema(); string(exported) != string(first) {
+			t.Fatal("exported document should not change between runs")
+		}
+	}
+	properties = overlapping.ToJsonSchema()["properties"].(map[string]interface{})
+	if name := properties["name"].(map[string]interface{}); name["maxLength"] != float64(10) {
+		t.Errorf("the validator which runs last should set maxLength, got %v", name)
+	}
+	born := properties["born"].(map[string]interface{})
+	if _, ok := born["format"]; ok {
+		t.Errorf("dates should not be exported with a format, got %v", born)
+	}
+	if _, ok := born[v0.ExtensionValidators].(map[string]v0.Constant)["IsValidDate"]; !ok {
+		t.Errorf("IsValidDate should be kept in the extensions: %v", born)
+	}
+	if tags := properties["tags"].(map[string]interface{}); tags["minItems"] != float64(3) {
+		t.Errorf("required items should keep the minItems of ArrayLengthMin, got %v", tags)
+	}
+	if labels := properties["labels"].(map[string]interface{}); labels["minItems"] != 1 {
+		t.Errorf("required items should need at least one item, got %v", labels)
+	}
+}
+
+func TestV0ImportJsonSchema(t *testing.T) {
+	var schematics v0.Schematics
+	untranslated, err := schematics.LoadJsonSchemaDocument("test-data/schema/jsonschema/example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(untranslated) != 3 {
+		t.Errorf("expected additionalProperties, exclusiveMaximum and the required reference to be untranslated, got %v", untranslated)
+	}
+	for _, keyword := range untranslated {
+		if keyword.Keyword == "required" && keyword.Path != "/required/2" {
+			t.Errorf("required key without a schema should point at the key, got %v", keyword)
+		}
+	}
+	fields := schematics.Schema.Fields
+	if sku, ok := fields["items.*.sku"]; !ok || !sku.IsRequired || sku.Validators["MatchRegex"].Attributes["regex"] != "^[A-Z]{3}-[0-9]+$" {
+		t.Errorf("unexpected field for items.*.sku: %v", sku)
+	}
+	if email := fields["customer.email"]; !email.IsRequired || email.DisplayName != "Email" {
+		t.Errorf("unexpected field for customer.email: %v", email)
+	}
+	if _, ok := fields["customer.tier"].Validators["StringTakenFromOptions"]; !ok {
+		t.Error("enum should be translated into StringTakenFromOptions")
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Error(errs.Error())
+	}
+	quantity := fields["items.*.quantity"]
+	if _, ok := quantity.Validators["IsInteger"]; !ok || quantity.Type != utils.TypeInteger {
+		t.Errorf("integer should be translated into IsInteger: %v", quantity)
+	}
+	order := map[string]interface{}{
+		"id":       "0b5e4a5e-0f8e-4b8e-9e5e-1b5e4a5e0f8e",
+		"customer": map[string]interface{}{"email": "john@example.com"},
+		"items":    []interface{}{map[string]interface{}{"sku": "ABC-1", "quantity": 1.5}},
+	}
+	if errs := schematics.Validate(order); !errs.HasErrors() {
+		t.Error("a fraction should not pass an integer")
+	}
+	order["items"] = []interface{}{map[string]interface{}{"sku": "ABC-1", "quantity": 2}}
+	if errs := schematics.Validate(order); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+
+	var exported v0.Schematics
+	err = exported.LoadJsonSchemaFile("test-data/schema/direct/v0/example-2.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imported v0.Schematics
+	_, err = imported.LoadJsonSchemaMap(exported.ToJsonSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for target, field := range exported.Schema.Fields {
+		importedField, ok := imported.Schema.Fields[target]
+		if !ok {
+			t.Errorf("%s is lost in the round trip", target)
+			continue
+		}
+		for name := range field.Validators {
+			if _, ok := importedField.Validators[name]; !ok && name != "InBetween" {
+				t.Errorf("validator %s of %s is lost in the round trip", name, target)
+			}
+		}
+	}
+}
+
+func TestApiV2ExportOpenAPI(t *testing.T) {
+	content, err := os.ReadFile("test-data/schema/api/v2/example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema apiv2.Schema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatal(err)
+	}
+	doc := schema.ToOpenAPI("users")
+	paths := doc["paths"].(map[string]interface{})
+	getUser, ok := paths["/users/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("path parameters should be converted, got %v", paths)
+	}
+	if parameters := getUser["parameters"].([]interface{}); len(parameters) != 3 {
+		t.Errorf("expected global header, path and query parameters, got %v", parameters)
+	}
+	id := getUser["parameters"].([]interface{})[1].(map[string]interface{})
+	if id["required"] != true || id["schema"].(map[string]interface{})["pattern"] != "^[0-9]+$" {
+		t.Errorf("path parameter schema should be built from the params, got %v", id)
+	}
+	createUser := paths["/users"].(map[string]interface{})["post"].(map[string]interface{})
+	body := createUser["requestBody"].(map[string]interface{})
+	if body["required"] != true {
+		t.Error("request body should be required")
+	}
+	bodySchema := body["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	user := bodySchema["properties"].(map[string]interface{})["user"].(map[string]interface{})
+	if user["required"].([]string)[0] != "email" {
+		t.Errorf("email should be required in the body, got %v", user)
+	}
+	authorization := doc["components"].(map[string]interface{})["parameters"].(map[string]interface{})["header-Authorization"].(map[string]interface{})
+	if authorization["schema"].(map[string]interface{})["pattern"] != "^Bearer .*$" {
+		t.Errorf("LIKE should be converted into a pattern, got %v", authorization)
+	}
+}
+
+func TestV0ValidatorErrorWithoutMessage(t *testing.T) {
+	var schematics v0.Schematics
+	err := schematics.LoadMap(map[string]interface{}{
+		"version": "1",
+		"fields": map[string]interface{}{
+			"user.email": map[string]interface{}{
+				"validators": map[string]interface{}{"IsEmail": map[string]interface{}{}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := schematics.Validate(map[string]interface{}{"user": map[string]interface{}{"email": "john"}})
+	if !errs.HasErrors() {
+		t.Fatal("a failing validator without a custom error should be reported")
+	}
+	if message := errs.Messages["user.email"].Message["en"]; message == "" {
+		t.Error("the error of the validator should be the message")
+	}
+}
+
+func TestV0RequiredObject(t *testing.T) {
+	var schematics v0.Schematics
+	err := schematics.LoadMap(map[string]interface{}{
+		"version": "1",
+		"fields": map[string]interface{}{
+			"user":       map[string]interface{}{"required": true},
+			"user.email": map[string]interface{}{"validators": map[string]interface{}{"IsEmail": map[string]interface{}{}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := schematics.Validate(map[string]interface{}{"user": map[string]interface{}{"email": "john@example.com"}})
+	if errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+	if errs = schematics.Validate(map[string]interface{}{"name": "John"}); !errs.HasErrors() {
+		t.Error("missing required object should not be valid")
+	}
+}
+
+func TestParseRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set("X-Request-Id", "1")
+	request, err := parsers.ParseRequest(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers := request["headers"].(map[string]string); headers["X-Request-Id"] != "1" {
+		t.Errorf("headers should be parsed, got %v", headers)
+	}
+	if request["path"] != "/users" || len(request["query"].(map[string]interface{})) != 0 {
+		t.Errorf("request without a query should be parsed, got %v", request)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/users?page=2", strings.NewReader(`{"user": {"name": "John"}}`))
+	if request, err = parsers.ParseRequest(r); err != nil {
+		t.Fatal(err)
+	}
+	if request["query"].(map[string]interface{})["page"] != "2" {
+		t.Errorf("query should be parsed, got %v", request["query"])
+	}
+}
+
+func TestApiV0GetSchematics(t *testing.T) {
+	schema := apiv0.Schema{Version: "1"}
+	fields := map[apiv0.TargetKey]apiv0.Field{
+		"user.email": {Required: true, Validators: map[apiv0.TargetKey]apiv0.Constant{"IsEmail": {}}},
+		"user.name":  {Validators: map[apiv0.TargetKey]apiv0.Constant{"IsString": {}}},
+	}
+	schematics, err := schema.GetSchematics("Body", &fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fieldType := schematics.Schema.Fields["user.name"].Type; fieldType != "" {
+		t.Errorf("fields without a type should not get one, got %s", fieldType)
+	}
+	if errs := schematics.Validate(map[string]interface{}{"user": map[string]interface{}{"email": "john@example.com", "name": "John"}}); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+	if errs := schematics.Validate(map[string]interface{}{"user": map[string]interface{}{"email": "john"}}); !errs.HasErrors() {
+		t.Error("invalid email should not be valid")
+	}
+	if errs := schematics.Validate(map[string]interface{}{"user": map[string]interface{}{"name": "John"}}); !errs.HasErrors() {
+		t.Error("missing required email should not be valid")
+	}
+}
+
+func TestApiV0ValidateRequest(t *testing.T) {
+	schema := apiv0.Schema{
+		Version: "1",
+		Global: apiv0.Global{Headers: map[apiv0.TargetKey]apiv0.Field{
+			"X-Api-Key": {Required: true, Validators: map[apiv0.TargetKey]apiv0.Constant{"IsString": {}}},
+		}},
+		Endpoints: map[apiv0.EndpointKey]apiv0.Endpoint{
+			"/users/:id": {Type: "GET", Headers: map[apiv0.TargetKey]apiv0.Field{
+				"X-Request-Id": {Required: true, Validators: map[apiv0.TargetKey]apiv0.Constant{"IsString": {}}},
+			}},
+		},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/users/5", nil)
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("missing global header should not be valid")
+	}
+	r.Header.Set("X-Api-Key", "key")
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("missing endpoint header should not be valid")
+	}
+	r.Header.Set("X-Request-Id", "1")
+	if errs := schema.ValidateRequest(r); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+	r = httptest.NewRequest(http.MethodGet, "/orders", nil)
+	r.Header.Set("X-Api-Key", "key")
+	if errs := schema.ValidateRequest(r); errs.HasErrors() {
+		t.Errorf("endpoints of other paths should not be validated, got %v", errs.GetStrings("en", "%target: %message"))
+	}
+}
+
+func TestApiV2QueryFields(t *testing.T) {
+	schema, err := apiv2.LoadJsonSchemaFile("test-data/schema/api/v2/query.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := schema.ValidateRequest(httptest.NewRequest(http.MethodGet, "/users?sort=asc", nil)); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+	if errs := schema.ValidateRequest(httptest.NewRequest(http.MethodGet, "/users?sort=up", nil)); !errs.HasErrors() {
+		t.Error("query fields should be validated")
+	}
+}
+
+func TestApiLegacyKeys(t *testing.T) {
+	loaders := map[string]func(string) (*apiv0.Schema, error){
+		"test-data/schema/api/v1/legacy.json": apiv1.LoadJsonSchemaFile,
+		"test-data/schema/api/v2/legacy.json": apiv2.LoadJsonSchemaFile,
+	}
+	for path, load := range loaders {
+		schema, err := load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		email := schema.Endpoints["/users"].Body["email"]
+		if !reflect.DeepEqual(email.DependsOn, []string{"name"}) {
+			t.Errorf("%s: DependsOn should be read, got %v", path, email.DependsOn)
+		}
+		if email.Validators["IsEmail"].ErrMsg != "email is not valid" {
+			t.Errorf("%s: the error of the validator should be read, got %+v", path, email.Validators)
+		}
+	}
+}
+
+func TestApiV2LoadOpenAPI(t *testing.T) {
+	schema, err := apiv2.LoadOpenAPIFile("test-data/schema/openapi/example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := schema.Endpoints["GET /users/:id"]; !ok {
+		t.Fatalf("endpoints should be keyed by the method and the path, got %v", schema.Endpoints)
+	}
+
+	content, err := os.ReadFile("test-data/schema/openapi/example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err = json.Unmarshal(content, &doc); err != nil {
+		t.Fatal(err)
+	}
+	_, untranslated, err := apiv2.FromOpenAPI(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]bool{
+		"/paths/~1users~1{id}/parameters/0/schema contentEncoding": false,
+		"/paths/~1users~1{id}/get/parameters/1 in":                 false,
+	}
+	for _, keyword := range untranslated {
+		key := keyword.Path + " " + keyword.Keyword
+		if _, ok := expected[key]; !ok {
+			t.Errorf("unexpected untranslated keyword: %s", key)
+		}
+		expected[key] = true
+	}
+	for key, found := range expected {
+		if !found {
+			t.Errorf("untranslated keyword not reported: %s", key)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/users/not-a-uuid", nil)
+	r.Header.Set("X-Request-Id", "1")
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("invalid path parameter should not be valid")
+	}
+	r = httptest.NewRequest(http.MethodGet, "/users/0b5e4a5e-0f8e-4b8e-9e5e-1b5e4a5e0f8e", nil)
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("missing header should not be valid")
+	}
+	r.Header.Set("X-Request-Id", "1")
+	if errs := schema.ValidateRequest(r); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"user": {"email": "john@example.com", "name": "John"}}`))
+	if errs := schema.ValidateRequest(r); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+	r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"user": {"email": "john", "name": "John Appleseed"}}`))
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("invalid body should not be valid")
+	}
+}
+
+func TestYamlSchemaFiles(t *testing.T) {
+	schematics, err := v2.LoadSchemaFile("test-data/schema/direct/v2/example-3.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !schematics.Schema.Fields["quantity"].IsRequired {
+		t.Error("quantity should be required")
+	}
+	errs := schematics.Validate(map[string]interface{}{"quantity": float64(0), "product_id": "p-1"})
+	if !errs.HasErrors() {
+		t.Error("quantity should be at least 1")
+	}
+
+	var invalid v0.Schematics
+	err = invalid.LoadSchemaFile("test-data/schema/direct/v0/invalid.yaml")
+	if err == nil || !strings.HasPrefix(err.Error(), "line 5:") {
+		t.Errorf("error should include the line of the offending field, got %v", err)
+	}
+}
+
+func TestV0SchemaReferences(t *testing.T) {
+	var schematics v0.Schematics
+	err := schematics.LoadSchemaFile("test-data/schema/includes/main.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := schematics.Schema.Fields
+	for _, target := range []v0.TargetKey{"billing.city", "billing.zip", "shipping.city", "shipping.zip", "email"} {
+		if _, ok := fields[target]; !ok {
+			t.Errorf("%s should be mounted", target)
+		}
+	}
+	if fields["billing.zip"].DependsOn[0] != "billing.city" {
+		t.Errorf("depends on should be mounted, got %v", fields["billing.zip"].DependsOn)
+	}
+	if when := fields["billing.state"].When; when == nil || when.Field != "billing.country" {
+		t.Errorf("conditions should be mounted, got %+v", when)
+	}
+	if when := fields["shipping.state"].When; when == nil || when.Field != "shipping.country" {
+		t.Errorf("every include should mount its own condition, got %+v", when)
+	}
+	if field := fields["billing.zip"].Validators["NotEqualsField"].Attributes["field"]; field != "billing.city" {
+		t.Errorf("the field of cross field validators should be mounted, got %v", field)
+	}
+	if _, ok := fields["billing.city"].Validators["MaxLengthAllowed"]; !ok {
+		t.Error("validator set should be resolved relative to the included file")
+	}
+	if _, ok := fields["shipping.zip"].Validators["IsString"]; ok {
+		t.Error("fields of the schema should override the included fields")
+	}
+	if fields["email"].Validators["IsEmail"].Error != "email is not valid" {
+		t.Error("validator set of the local group should be resolved")
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Error(errs.Error())
+	}
+
+	fsys := os.DirFS("test-data/schema/includes")
+	var fromFS v0.Schematics
+	if err := fromFS.LoadSchemaFS(fsys, "main.json"); err != nil {
+		t.Error(err)
+	}
+
+	var cyclic v0.Schematics
+	err = cyclic.LoadSchemaFile("test-data/schema/includes/cycle-a.json")
+	if err == nil || !strings.Contains(err.Error(), "reference cycle") {
+		t.Errorf("reference cycle should be detected, got %v", err)
+	}
+}
+
+func TestV0SchemaExtends(t *testing.T) {
+	var schematics v0.Schematics
+	err := schematics.LoadSchemaFile("test-data/schema/inheritance/customer-create.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := schematics.Schema.Fields
+	if schematics.Schema.Version != "1.0.0" {
+		t.Errorf("version should be inherited, got %s", schematics.Schema.Version)
+	}
+	if _, ok := fields["notes"]; ok {
+		t.Error("notes should be removed")
+	}
+	if _, ok := fields["password"]; !ok {
+		t.Error("password should be added")
+	}
+	if !fields["email"].IsRequired {
+		t.Error("email should be required")
+	}
+	if _, ok := fields["email"].Validators["IsEmail"]; !ok {
+		t.Error("IsEmail should be inherited")
+	}
+	if _, ok := fields["email"].Operators["LowerCase"]; ok {
+		t.Error("LowerCase should be removed")
+	}
+	if address := fields["address"]; address.IsRequired || address.Closed || address.Type != "object" {
+		t.Errorf("remove_flags should clear the flags of the parent field, got %v", address)
+	}
+	maxLength := fields["name"].Validators["MaxLengthAllowed"]
+	if maxLength.Attributes["max"] != float64(50) || maxLength.Error != "name is too long" {
+		t.Errorf("MaxLengthAllowed should be merged, got %v", maxLength)
+	}
+	if _, ok := fields["nickname"].Validators["IsString"]; ok {
+		t.Error("nickname should replace the parent field")
+	}
+
+	var origin v0.FieldOrigin
+	for _, resolved := range schematics.ResolvedView() {
+		if resolved.Target == "name" {
+			origin = resolved.Origin
+		}
+	}
+	if !strings.HasSuffix(origin.Validators["MaxLengthAllowed"], "customer-create.yaml") || !strings.HasSuffix(origin.Validators["IsString"], "customer.json") {
+		t.Errorf("origins are not tracked, got %v", origin.Validators)
+	}
+
+	errs := schematics.Validate(map[string]interface{}{"name": "john", "nickname": "j"})
+	messages := strings.Join(*errs.GetStrings("en", "%target"), ",")
+	for _, target := range []string{"email", "password", "nickname"} {
+		if !strings.Contains(messages, target) {
+			t.Errorf("%s should fail, got %s", target, messages)
+		}
+	}
+
+	var overridden v0.Schematics
+	if err := overridden.LoadSchemaFile("test-data/schema/inheritance/customer-conflict.json"); err != nil {
+		t.Fatal(err)
+	}
+	if overridden.Schema.Fields["name"].Type != "number" {
+		t.Error("child should override the type by default")
+	}
+	conflicting := v0.Schematics{ConflictStrategy: v0.ConflictError}
+	err = conflicting.LoadSchemaFile("test-data/schema/inheritance/customer-conflict.json")
+	if err == nil || !strings.Contains(err.Error(), "type is already defined") {
+		t.Errorf("conflict should be reported, got %v", err)
+	}
+
+	var relabelled v0.Schematics
+	if err := relabelled.LoadSchemaFile("test-data/schema/inheritance/customer-l10n-conflict.json"); err != nil {
+		t.Fatal(err)
+	}
+	if label := relabelled.Schema.Fields["email"].L10n["en"]; label != "E-mail" {
+		t.Errorf("child should override the l10n by default, got %v", label)
+	}
+	conflicting = v0.Schematics{ConflictStrategy: v0.ConflictError}
+	err = conflicting.LoadSchemaFile("test-data/schema/inheritance/customer-l10n-conflict.json")
+	if err == nil || !strings.Contains(err.Error(), "l10n en is already defined") {
+		t.Errorf("l10n conflict should be reported, got %v", err)
+	}
+}
+
+func TestSchemaConversion(t *testing.T) {
+	upgraded, err := v2.UpgradeFile("test-data/schema/direct/v1/example-1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := upgraded.ToV0()
+	name := base.Fields["user.name"]
+	if name.DisplayName != "Name" || name.Type != "string" || name.AdditionalInformation["section"] != "profile" {
+		t.Errorf("field properties should be kept, got %+v", name)
+	}
+	if upgraded.Fields[0].TargetKey != "user.name" || upgraded.Fields[0].Validators[0].Name != "MaxLengthAllowed" {
+		t.Error("fields and validators should keep the order of the file")
+	}
+
+	exported, err := upgraded.Export(utils.FormatJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := v2.FromV1(upgraded.ToV1()).Export(utils.FormatJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(exported) != string(roundTrip) {
+		t.Errorf("round trip should be lossless, got\n%s\nwant\n%s", roundTrip, exported)
+	}
+
+	yamlContent, err := upgraded.Export(utils.FormatYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromYaml v2.Schema
+	if err := utils.UnmarshalSchema(yamlContent, utils.FormatYaml, &fromYaml); err != nil {
+		t.Fatal(err)
+	}
+	if fromYamlJson, _ := fromYaml.Export(utils.FormatJson); string(fromYamlJson) != string(exported) {
+		t.Errorf("yaml export should be lossless, got\n%s", yamlContent)
+	}
+
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/example-2.json"); err != nil {
+		t.Fatal(err)
+	}
+	baseExport, _ := schematics.Schema.Export(utils.FormatJson)
+	convertedExport, _ := v2.FromV0(&schematics.Schema).ToV0().Export(utils.FormatJson)
+	if string(baseExport) != string(convertedExport) {
+		t.Errorf("v0 round trip should be lossless, got\n%s\nwant\n%s", convertedExport, baseExport)
+	}
+
+	content, err := os.ReadFile("test-data/schema/api/v2/example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var api apiv2.Schema
+	if err := json.Unmarshal(content, &api); err != nil {
+		t.Fatal(err)
+	}
+	apiExport, _ := api.ToV0().Export(utils.FormatJson)
+	apiRoundTrip, _ := apiv2.FromV0(apiv2.FromV1(api.ToV1()).ToV0()).ToV0().Export(utils.FormatJson)
+	if string(apiExport) != string(apiRoundTrip) {
+		t.Errorf("api round trip should be lossless, got\n%s\nwant\n%s", apiRoundTrip, apiExport)
+	}
+}
+
+type structAddress struct {
+	City string `json:"city" schematics:"required,MaxLengthAllowed=max:20"`
+	Zip  string `json:"zip,omitempty" schematics:"MatchRegex=regex:^[0-9]{5}$"`
+}
+
+type structBase struct {
+	ID string `json:"id" schematics:"IsValidUuid"`
+}
+
+type structUser struct {
+	structBase
+	Name      string          `json:"name" schematics:"required,MaxLengthAllowed=max:50"`
+	Email     string          `json:"email" schematics:"required,IsEmail"`
+	Status    string          `json:"status" schematics:"StringTakenFromOptions=options:active;disabled"`
+	Tags      []string        `json:"tags" schematics:"ArrayLengthMax=max:2,dive,MinLengthAllowed=min:3"`
+	Addresses []structAddress `json:"addresses"`
+	Manager   *structAddress  `json:"manager"`
+	Nickname  string          `json:"nickname"`
+	Secret    string          `json:"-" schematics:"required"`
+	internal  string
+}
+
+func TestV0LoadStruct(t *testing.T) {
+	var schematics v0.Schematics
+	if err := schematics.LoadStruct(structUser{}); err != nil {
+		t.Fatal(err)
+	}
+	fields := schematics.Schema.Fields
+	for _, target := range []v0.TargetKey{"id", "name", "email", "status", "tags", "tags.*", "addresses.*.city", "addresses.*.zip", "manager.city"} {
+		if _, ok := fields[target]; !ok {
+			t.Errorf("%s should be generated", target)
+		}
+	}
+	if _, ok := fields["Secret"]; ok {
+		t.Error("fields skipped by the json tag should not be generated")
+	}
+	options := fields["status"].Validators["StringTakenFromOptions"].Attributes["options"]
+	if !reflect.DeepEqual(options, []interface{}{"active", "disabled"}) {
+		t.Errorf("options should be a list, got %v", options)
+	}
+	if fields["name"].Validators["MaxLengthAllowed"].Attributes["max"] != float64(50) || !fields["name"].IsRequired {
+		t.Errorf("name rules are not generated, got %+v", fields["name"])
+	}
+	if _, ok := fields["name"].Validators["IsRequired"]; ok {
+		t.Error("required should only set the flag")
+	}
+	if nickname, ok := fields["nickname"]; !ok || len(nickname.Validators) != 0 || nickname.Type != utils.TypeString {
+		t.Errorf("untagged fields should be plain targets, got %+v", nickname)
+	}
+	if fields["addresses.*.zip"].Validators["MatchRegex"].Attributes["regex"] != "^[0-9]{5}$" {
+		t.Errorf("regex should be kept, got %v", fields["addresses.*.zip"].Validators["MatchRegex"].Attributes)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Error(errs.Error())
+	}
+
+	errs := schematics.Validate(map[string]interface{}{
+		"name":      "john",
+		"email":     "not-an-email",
+		"tags":      []interface{}{"go", "json"},
+		"addresses": []interface{}{map[string]interface{}{"zip": "123"}},
+	})
+	messages := strings.Join(*errs.GetStrings("en", "%target"), ",")
+	for _, target := range []string{"email", "tags.0", "addresses.*.city", "addresses.0.zip"} {
+		if !strings.Contains(messages, target) {
+			t.Errorf("%s should fail, got %s", target, messages)
+		}
+	}
+
+	schematics.Strict = true
+	errs = schematics.Validate(map[string]interface{}{
+		"name":      "john",
+		"email":     "john@example.com",
+		"nickname":  "jo",
+		"nick":      "jo",
+		"addresses": []interface{}{map[string]interface{}{"city": "Berlin"}},
+		"manager":   map[string]interface{}{"city": "Berlin"},
+	})
+	if messages := strings.Join(*errs.GetStrings("en", "%target"), ","); messages != "nick" {
+		t.Errorf("only keys outside the struct should be unknown, got %s", messages)
+	}
+
+	type node struct {
+		Children []node `json:"children"`
+	}
+	var recursive v0.Schematics
+	if err := recursive.LoadStruct(node{}); err == nil {
+		t.Error("recursive types should be reported")
+	}
+	var unknown v0.Schematics
+	if err := unknown.LoadStruct(struct {
+		Name string `schematics:"IsStrnig"`
+	}{}); err == nil || !strings.Contains(err.Error(), "IsStrnig") {
+		t.Errorf("unknown validators should be reported, got %v", err)
+	}
+}
+
+func TestV0InferSchema(t *testing.T) {
+	var inference v0.Inference
+	for _, path := range []string{"test-data/data/samples/orders-1.json", "test-data/data/samples/orders-2.json"} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inference.AddSample(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fields := inference.Schema().Fields
+
+	expected := map[v0.TargetKey]struct {
+		fieldType string
+		required  bool
+		validator string
+	}{
+		"id":               {utils.TypeString, true, "IsValidUuid"},
+		"status":           {utils.TypeString, true, "StringTakenFromOptions"},
+		"total":            {utils.TypeNumber, true, "IsNumber"},
+		"created_at":       {utils.TypeDate, true, "IsValidDate"},
+		"gift":             {utils.TypeBoolean, false, ""},
+		"customer.email":   {utils.TypeString, true, "IsEmail"},
+		"customer.note":    {utils.TypeString, false, "IsString"},
+		"items.*.sku":      {utils.TypeString, true, "IsString"},
+		"items.*.quantity": {utils.TypeNumber, true, "IsNumber"},
+		"items.*.tags.*":   {utils.TypeString, false, "IsString"},
+	}
+	for target, want := range expected {
+		field, ok := fields[target]
+		if !ok {
+			t.Errorf("%s should be inferred", target)
+			continue
+		}
+		if field.Type != want.fieldType || field.IsRequired != want.required {
+			t.Errorf("%s: got type %s required %v", target, field.Type, field.IsRequired)
+		}
+		if _, ok := field.Validators[want.validator]; want.validator != "" && !ok {
+			t.Errorf("%s: %s should be suggested, got %v", target, want.validator, field.Validators)
+		}
+	}
+	options := fields["status"].Validators["StringTakenFromOptions"].Attributes["options"]
+	if !reflect.DeepEqual(options, []interface{}{"paid", "pending"}) {
+		t.Errorf("status options should be suggested, got %v", options)
+	}
+	if _, ok := fields["items.*.sku"].Validators["StringTakenFromOptions"]; ok {
+		t.Error("unique values should not get an option list")
+	}
+}
+
+func TestV0SchemaDiff(t *testing.T) {
+	var old, next v0.Schematics
+	if err := old.LoadSchemaFile("test-data/schema/direct/v0/example-2.json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := next.LoadSchemaFile("test-data/schema/diff/example-2-next.json"); err != nil {
+		t.Fatal(err)
+	}
+	diff := old.Diff(&next)
+
+	expected := map[string]bool{
+		"user.website field-removed":             false,
+		"user.country field-added":               true,
+		"user.phone field-added":                 false,
+		"user.email required-removed":            false,
+		"user.name attribute-tightened":          true,
+		"user.age attribute-loosened":            false,
+		"user.status attribute-loosened":         false,
+		"user.addresses.*.city depends-on-added": true,
+	}
+	if len(diff.Changes) != len(expected) {
+		t.Errorf("expected %d changes, got %+v", len(expected), diff.Changes)
+	}
+	for _, change := range diff.Changes {
+		breaking, ok := expected[string(change.Target)+" "+change.Kind]
+		if !ok {
+			t.Errorf("unexpected change %+v", change)
+		} else if breaking != change.Breaking {
+			t.Errorf("%s %s should have breaking %v", change.Target, change.Kind, breaking)
+		}
+	}
+	if err := diff.CheckVersion(); err == nil {
+		t.Error("breaking changes should need a new major version")
+	}
+	diff.NewVersion = "2.0.0"
+	if err := diff.CheckVersion(); err != nil {
+		t.Error(err)
+	}
+
+	reverse := v0.DiffSchemas(&next.Schema, &old.Schema)
+	for _, change := range reverse.Changes {
+		if change.Target == "user.status" && !change.Breaking {
+			t.Error("removing options should be breaking")
+		}
+	}
+
+	yes, no := true, false
+	before := v0.Schema{Version: "1.0.0", Fields: map[v0.TargetKey]v0.Field{
+		"nickname": {Nullable: &yes, AllowEmpty: &no, Default: "guest", Validators: map[string]v0.Constant{"IsString": {}}},
+		"address":  {Closed: true, Validators: map[string]v0.Constant{}},
+		"state":    {When: &v0.Condition{Field: "country", Equals: "US"}, Validators: map[string]v0.Constant{"IsString": {}}},
+		"zip":      {Validators: map[string]v0.Constant{"IsString": {When: &v0.Condition{Field: "country", Equals: "US"}}}},
+	}}
+	after := v0.Schema{Version: "1.0.1", Fields: map[v0.TargetKey]v0.Field{
+		"nickname": {AllowEmpty: &yes, Validators: map[string]v0.Constant{"IsString": {}}},
+		"address":  {Validators: map[string]v0.Constant{}},
+		"state":    {When: &v0.Condition{Field: "country", In: []interface{}{"US", "CA"}}, Validators: map[string]v0.Constant{"IsString": {}}},
+		"zip":      {Validators: map[string]v0.Constant{"IsString": {}}},
+	}}
+	expected = map[string]bool{
+		"nickname nullable-changed":    true,
+		"nickname allow-empty-changed": false,
+		"nickname default-changed":     true,
+		"address closed-removed":       false,
+		"state when-changed":           true,
+		"zip when-changed":             true,
+	}
+	tightened := v0.DiffSchemas(&before, &after)
+	if len(tightened.Changes) != len(expected) {
+		t.Errorf("expected %d changes, got %+v", len(expected), tightened.Changes)
+	}
+	for _, change := range tightened.Changes {
+		breaking, ok := expected[string(change.Target)+" "+change.Kind]
+		if !ok {
+			t.Errorf("unexpected change %+v", change)
+		} else if breaking != change.Breaking {
+			t.Errorf("%s %s should have breaking %v", change.Target, change.Kind, breaking)
+		}
+	}
+	if err := tightened.CheckVersion(); err == nil {
+		t.Error("tightening nullable, default and when should need a new major version")
+	}
+	for _, change := range v0.DiffSchemas(&after, &before).Changes {
+		if breaking := change.Kind == v0.ChangeAllowEmptyChanged || change.Kind == v0.ChangeClosedAdded || change.Target == "state"; breaking != change.Breaking {
+			t.Errorf("%s %s should have breaking %v", change.Target, change.Kind, breaking)
+		}
+	}
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	var schemas registry.Registry
+	schemas.Validators.RegisterValidator("IsTitle", func(i interface{}, _ map[string]interface{}) error {
+		if s, ok := i.(string); !ok || s == "" || strings.ToUpper(s[:1]) != s[:1] {
+			return errors.New("value should be a title")
+		}
+		return nil
+	})
+	if err := schemas.LoadDir("test-data/registry"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(schemas.Names(), []string{"shop/orders", "users"}) {
+		t.Errorf("unexpected names %v", schemas.Names())
+	}
+	if !reflect.DeepEqual(schemas.Versions("users"), []string{"1.0.0", "1.1.0", "2.0.0"}) {
+		t.Errorf("unexpected versions %v", schemas.Versions("users"))
+	}
+
+	expected := map[string]string{
+		"":                "2.0.0",
+		"latest":          "2.0.0",
+		"1.0.0":           "1.0.0",
+		"^1.0":            "1.1.0",
+		"~1.0.0":          "1.0.0",
+		"1.x":             "1.1.0",
+		">=1.0.0 <1.1.0":  "1.0.0",
+		"<1.0.0 || 2.0.0": "2.0.0",
+	}
+	for constraint, version := range expected {
+		entry, err := schemas.GetEntry("users", constraint)
+		if err != nil {
+			t.Errorf("%s: %v", constraint, err)
+		} else if entry.Version != version {
+			t.Errorf("%s: expected %s, got %s", constraint, version, entry.Version)
+		}
+	}
+	if _, err := schemas.Get("users", "^3"); err == nil {
+		t.Error("missing versions should be reported")
+	}
+	if _, err := schemas.Get("products", ""); err == nil {
+		t.Error("missing schemas should be reported")
+	}
+
+	users, err := schemas.Get("users", "1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := users.Validate(map[string]interface{}{"name": "john"})
+	if !errs.HasErrors() || !strings.Contains(strings.Join(*errs.GetStrings("en", "%message"), ","), "title") {
+		t.Error("custom validators of the registry should be shared")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			orders, err := schemas.Get("shop/orders", "^0.3")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			orders.Validate(map[string]interface{}{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7"})
+		}()
+	}
+	if err := schemas.LoadFile("shop/orders", "test-data/registry/shop/orders.json"); err != nil {
+		t.Error(err)
+	}
+	wg.Wait()
+}
+
+func TestSchemaWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/users.json"
+	write := func(version string, validator string) {
+		content := `{"version": "` + version + `", "fields": {"name": {"required": true, "validators": {"` + validator + `": {}}}}}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("1.0.0", "IsString")
+
+	var failures []string
+	watcher := registry.Watcher{OnError: func(path string, err error) {
+		failures = append(failures, path)
+	}}
+	if err := watcher.AddDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	old, err := watcher.Registry.Get("users", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write("1.0.0", "IsNumber")
+	watcher.Check()
+	current, err := watcher.Registry.Get("users", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current == old {
+		t.Fatal("changed schema should be reloaded")
+	}
+	if old.Validate(map[string]interface{}{"name": "john"}).HasErrors() {
+		t.Error("old schematics should keep validating with the old schema")
+	}
+	if !current.Validate(map[string]interface{}{"name": "john"}).HasErrors() {
+		t.Error("reloaded schema should be used")
+	}
+
+	write("1.0.0", "IsUnknownValidator")
+	watcher.Check()
+	if len(failures) != 1 || failures[0] != path {
+		t.Errorf("failed reloads should be reported, got %v", failures)
+	}
+	if reloaded, _ := watcher.Registry.Get("users", ""); reloaded != current {
+		t.Error("last good schema should be kept")
+	}
+
+	write("1.1.0", "IsString")
+	watcher.Check()
+	if !reflect.DeepEqual(watcher.Registry.Versions("users"), []string{"1.1.0"}) {
+		t.Errorf("unexpected versions %v", watcher.Registry.Versions("users"))
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	watcher.Check()
+	if len(watcher.Registry.Names()) != 0 {
+		t.Errorf("removed schema files should be unregistered, got %v", watcher.Registry.Names())
+	}
+
+	broken := t.TempDir()
+	for name, validator := range map[string]string{"a.json": "IsString", "b.json": "IsUnknownValidator"} {
+		content := `{"version": "1.0.0", "fields": {"name": {"validators": {"` + validator + `": {}}}}}`
+		if err := os.WriteFile(broken+"/"+name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := watcher.AddDir(broken); err == nil {
+		t.Error("a directory with a broken schema should fail")
+	}
+	if len(watcher.Registry.Names()) != 0 {
+		t.Errorf("no schema of a failing directory should be registered, got %v", watcher.Registry.Names())
+	}
+
+	registered := &v0.Schematics{Schema: v0.Schema{Version: "1.0.0"}}
+	if err := watcher.Registry.Register("a", registered); err != nil {
+		t.Fatal(err)
+	}
+	invalid := t.TempDir()
+	for name, version := range map[string]string{"a.json": "1.0.0", "b.json": "not-a-version"} {
+		content := `{"version": "` + version + `", "fields": {"name": {"validators": {"IsString": {}}}}}`
+		if err := os.WriteFile(invalid+"/"+name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := watcher.AddDir(invalid); err == nil {
+		t.Error("a directory with an invalid version should fail")
+	}
+	if restored, err := watcher.Registry.Get("a", "1.0.0"); err != nil || restored != registered {
+		t.Errorf("the replaced schema should be restored, got %v %v", restored, err)
+	}
+}
+
+func TestLoadSchemasFS(t *testing.T) {
+	files := fstest.MapFS{}
+	for _, name := range []string{"main.json", "common/address.yaml", "common/validators.json"} {
+		content, err := os.ReadFile("test-data/schema/includes/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files["schemas/"+name] = &fstest.MapFile{Data: content}
+	}
+	names, err := utils.GlobFS(files, "schemas/**/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"schemas/common/validators.json", "schemas/main.json"}) {
+		t.Errorf("unexpected matches %v", names)
+	}
+
+	schemas, err := v0.LoadSchemasFS(files, "schemas/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mainSchema, exists := schemas["schemas/main.json"]
+	if len(schemas) != 1 || !exists {
+		t.Fatalf("unexpected schemas %v", schemas)
+	}
+	if _, exists := mainSchema.Schema.Fields["billing.zip"]; !exists {
+		t.Error("references should be resolved inside the file system")
+	}
+
+	content, err := os.ReadFile("test-data/schema/direct/v2/example-3.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schematics, err := v2.LoadSchemaReader(bytes.NewReader(content), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schematics.Schema.Fields) == 0 {
+		t.Error("schema should be read from the reader")
+	}
+
+	apis, err := apiv2.LoadSchemasFS(os.DirFS("test-data/schema/api"), "v2/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api := apis["v2/example.json"]; api == nil || len(api.Endpoints) == 0 {
+		t.Errorf("unexpected api schemas %v", apis)
+	}
+
+	var schemaRegistry registry.Registry
+	schemaRegistry.Validators.RegisterValidator("IsTitle", func(i interface{}, _ map[string]interface{}) error {
+		return nil
+	})
+	if err := schemaRegistry.LoadFS(os.DirFS("test-data/registry"), "**"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(schemaRegistry.Versions("users"), []string{"1.0.0", "1.1.0", "2.0.0"}) {
+		t.Errorf("unexpected versions %v", schemaRegistry.Versions("users"))
+	}
+}
+
+func TestV0Conditions(t *testing.T) {
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/conditions.json"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Fatal(errs.Error())
+	}
+
+	cases := []struct {
+		data     map[string]interface{}
+		expected []string
+	}{
+		{map[string]interface{}{"country": "US"}, nil},
+		{map[string]interface{}{"country": "DE"}, []string{"vat_number"}},
+		{map[string]interface{}{"country": "FR", "vat_number": "FR123", "company": "acme"}, nil},
+		{map[string]interface{}{"country": "US", "type": "business", "company": "acme"}, []string{"company"}},
+		{map[string]interface{}{"country": "US", "items": []interface{}{
+			map[string]interface{}{"kind": "sale", "price": 150},
+			map[string]interface{}{"kind": "regular", "price": 150},
+			map[string]interface{}{"kind": "sale", "price": 50},
+		}}, []string{"items.0.price"}},
+		{map[string]interface{}{"country": "US", "coupon": "SUMMER", "items": []interface{}{
+			map[string]interface{}{"kind": "sale", "price": 150},
+		}}, nil},
+		{map[string]interface{}{"country": "US", "company": "international business machines"}, []string{"company"}},
+		{map[string]interface{}{"country": "US", "type": "enterprise", "company": "international business machines"}, nil},
+	}
+	for i, c := range cases {
+		var targets []string
+		if errs := schematics.Validate(c.data); errs.HasErrors() {
+			for target := range errs.Messages {
+				targets = append(targets, string(target))
+			}
+		}
+		sort.Strings(targets)
+		if !reflect.DeepEqual(targets, c.expected) {
+			t.Errorf("case %d: expected errors on %v, got %v", i, c.expected, targets)
+		}
+	}
+
+	// negative comparisons hold for a missing field like a not condition does
+	long := map[string]interface{}{"country": "US", "company": "international business machines"}
+	for _, when := range []*v0.Condition{
+		{Field: "type", NotIn: []interface{}{"enterprise"}},
+		{Not: &v0.Condition{Field: "type", Equals: "enterprise"}},
+	} {
+		company := schematics.Schema.Fields["company"]
+		company.Validators["MaxLengthAllowed"] = v0.Constant{Attributes: map[string]interface{}{"max": 20}, When: when}
+		if !schematics.Validate(long).HasErrors() {
+			t.Errorf("condition %+v should hold without the type", when)
+		}
+	}
+
+	doc := schematics.ToJsonSchema()
+	properties := doc["properties"].(map[string]interface{})
+	if required, _ := doc["required"].([]string); utils.StringInStrings("vat_number", required) {
+		t.Errorf("a field with a condition should not be required by the json schema, got %v", required)
+	}
+	if vat := properties["vat_number"].(map[string]interface{}); vat[v0.ExtensionWhen] == nil || vat["type"] != nil {
+		t.Errorf("the rules of a field with a condition should be kept in the extensions, got %v", vat)
+	}
+	company := properties["company"].(map[string]interface{})
+	if _, exists := company["maxLength"]; exists {
+		t.Errorf("validators with a condition should not be exported as keywords, got %v", company)
+	}
+	var imported v0.Schematics
+	if _, err := imported.LoadJsonSchemaMap(doc); err != nil {
+		t.Fatal(err)
+	}
+	if vat := imported.Schema.Fields["vat_number"]; vat.When == nil || !vat.Required() {
+		t.Errorf("the condition of the field should be imported, got %+v", vat)
+	}
+	if max := imported.Schema.Fields["company"].Validators["MaxLengthAllowed"]; max.When == nil {
+		t.Errorf("the condition of the validator should be imported, got %+v", max)
+	}
+
+	schematics.Schema.Fields["vat_number"] = v0.Field{When: &v0.Condition{Equals: "DE"}}
+	if errs := schematics.Compile(); !errs.HasErrors() || !strings.Contains(errs.Error(), "condition should have a field") {
+		t.Error("conditions without a field should be reported")
+	}
+}
+
+func TestV0CrossFieldValidators(t *testing.T) {
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/cross-fields.json"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Fatal(errs.Error())
+	}
+
+	valid := map[string]interface{}{
+		"start_date": "2024-01-01",
+		"end_date":   "2024-02-01",
+		"account":    map[string]interface{}{"password": "secret", "password_confirm": "secret"},
+		"discount":   10,
+		"total":      100,
+		"items": []interface{}{
+			map[string]interface{}{"ordered_at": "2024-01-01", "shipped_at": "2024-01-02"},
+		},
+	}
+	if errs := schematics.Validate(valid); errs.HasErrors() {
+		t.Errorf("unexpected errors %v", *errs.GetStrings("en", "%target: %message"))
+	}
+
+	invalid := map[string]interface{}{
+		"start_date": "2024-02-01",
+		"end_date":   "2024-01-01",
+		"account":    map[string]interface{}{"password": "secret", "password_confirm": "secrets"},
+		"discount":   150,
+		"total":      100,
+		"items": []interface{}{
+			map[string]interface{}{"ordered_at": "2024-01-01", "shipped_at": "2024-01-02"},
+			map[string]interface{}{"ordered_at": "2024-01-05", "shipped_at": "2024-01-02"},
+		},
+	}
+	errs := schematics.Validate(invalid)
+	expected := map[errorHandler.Target]string{
+		"end_date":                 "start_date",
+		"account.password_confirm": "account.password",
+		"discount":                 "total",
+		"items.1.shipped_at":       "items.1.ordered_at",
+	}
+	if errs == nil || len(errs.Messages) != len(expected) {
+		t.Fatalf("expected errors on %v, got %v", expected, errs)
+	}
+	for target, related := range expected {
+		err, exists := errs.Messages[target]
+		if !exists || err.RelatedTarget != related || err.RelatedValue == nil {
+			t.Errorf("%s: expected a comparison with %s, got %+v", target, related, err)
+		}
+	}
+	if errs.Messages["account.password_confirm"].Message["en"] != "passwords do not match" {
+		t.Error("custom error of the comparison should be used")
+	}
+
+	delete(invalid, "total")
+	if errs := schematics.Validate(invalid); errs != nil {
+		if _, exists := errs.Messages["discount"]; exists {
+			t.Error("comparisons with a missing field should be skipped")
+		}
+	}
+}
+
+func TestV0ValidatorOrder(t *testing.T) {
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/ordered.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	password := schematics.Schema.Fields["password"]
+	if order := password.OrderedValidators(); !reflect.DeepEqual(order, []string{"IsString", "LeastOneDigit", "MinLengthAllowed", "LeastOneUpperCase"}) {
+		t.Errorf("unexpected order %v", order)
+	}
+
+	expected := map[string]string{
+		"password": "LeastOneDigit",
+		"code":     "LeastOneDigit",
+	}
+	for i := 0; i < 20; i++ {
+		errs := schematics.Validate(map[string]interface{}{"password": "abc", "code": "abcd"})
+		for target, validator := range expected {
+			if got := errs.Messages[errorHandler.Target(target)].Validator; got != validator {
+				t.Fatalf("%s: expected %s to fail first, got %s", target, validator, got)
+			}
+		}
+	}
+	errs := schematics.Validate(map[string]interface{}{"password": 42})
+	if got := errs.Messages["password"].Validator; got != "IsString" {
+		t.Errorf("type checks should run first, got %s", got)
+	}
+
+	converted := v2.FromV0(&schematics.Schema)
+	var names []string
+	for _, field := range converted.Fields {
+		if field.TargetKey == "password" {
+			for _, validator := range field.Validators {
+				names = append(names, validator.Name)
+			}
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"LeastOneDigit", "MinLengthAllowed", "LeastOneUpperCase", "IsString"}) {
+		t.Errorf("declared order should be kept by the conversion, got %v", names)
+	}
+	code := converted.ToV0().Fields["code"]
+	if order := code.OrderedValidators(); !reflect.DeepEqual(order, []string{"LeastOneDigit", "MaxLengthAllowed"}) {
+		t.Errorf("priority should be kept by the conversion, got %v", order)
+	}
+
+	for _, format := range []string{utils.FormatJson, utils.FormatYaml} {
+		exported, err := schematics.Schema.Export(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var reloaded v0.Schematics
+		if err := reloaded.LoadSchemaReader(bytes.NewReader(exported), format); err != nil {
+			t.Fatal(err)
+		}
+		if order := reloaded.Schema.Fields["password"].ValidatorOrder; !reflect.DeepEqual(order, []string{"LeastOneDigit", "MinLengthAllowed", "LeastOneUpperCase", "IsString"}) {
+			t.Errorf("%s: declared order should be kept by the export, got %v", format, order)
+		}
+	}
+}
+
+func TestApiValidatorOrder(t *testing.T) {
+	declared := []string{"MinLengthAllowed", "IsString", "LeastOneDigit"}
+	var components []apiv2.Component
+	for _, name := range declared {
+		components = append(components, apiv2.Component{Name: name})
+	}
+	schema := apiv2.Schema{Version: "1.0.0", Endpoints: map[string]apiv2.Endpoint{
+		"users": {Path: "/users", Type: "POST", Body: []apiv2.Field{{Key: "password", Validators: components}}},
+	}}
+
+	basic := schema.ToV0()
+	password := basic.Endpoints["users"].Body["password"]
+	if !reflect.DeepEqual(password.ValidatorOrder, declared) {
+		t.Errorf("declared order should be kept by ToV0, got %v", password.ValidatorOrder)
+	}
+	exported, err := basic.Export(utils.FormatYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reloaded apiv0.Schema
+	if err := reloaded.LoadSchemaReader(bytes.NewReader(exported), utils.FormatYaml); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, converted := range []*apiv2.Schema{apiv2.FromV0(basic), apiv2.FromV0(&reloaded)} {
+		var names []string
+		for _, validator := range converted.Endpoints["users"].Body[0].Validators {
+			names = append(names, validator.Name)
+		}
+		if !reflect.DeepEqual(names, declared) {
+			t.Errorf("declared order should be kept from v2 to v0 and back, got %v", names)
+		}
+	}
+}
+
+func TestV0ReportAllErrors(t *testing.T) {
+	schematics := v0.Schematics{ReportAllErrors: true}
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/ordered.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]interface{}{"password": "abc", "code": "abcd"}
+	errs := schematics.Validate(data)
+	expected := map[errorHandler.Target][]string{
+		"password": {"LeastOneDigit", "MinLengthAllowed", "LeastOneUpperCase"},
+		"code":     {"LeastOneDigit", "MaxLengthAllowed"},
+	}
+	for target, names := range expected {
+		var got []string
+		for _, err := range errs.ErrorsOf(target) {
+			got = append(got, err.Validator)
+		}
+		if !reflect.DeepEqual(got, names) {
+			t.Errorf("%s: expected failures %v, got %v", target, names, got)
+		}
+		if errs.Messages[target].Validator != names[0] {
+			t.Errorf("%s: messages should keep the first failure", target)
+		}
+	}
+	if messages := errs.GetStrings("en", "%target: %message"); messages == nil || len(*messages) != 5 {
+		t.Errorf("expected 5 messages, got %v", messages)
+	}
+	if all := errs.GetErrors("en", ""); all == nil || len(*all) != 5 {
+		t.Errorf("expected 5 errors, got %v", all)
+	}
+
+	var merged errorHandler.Errors
+	merged.MergeErrors(errs)
+	if len(merged.ErrorsOf("password")) != 3 {
+		t.Error("merging should keep all the failures")
+	}
+
+	password := schematics.Schema.Fields["password"]
+	if failures := password.ValidateAll("abc", schematics.Validators.ValidationFns, nil); len(failures) != 3 {
+		t.Errorf("expected 3 failures, got %v", failures)
+	}
+	if err := password.Validate("abc", schematics.Validators.ValidationFns, nil); err == nil || err.Validator != "LeastOneDigit" {
+		t.Errorf("validate should stop on the first failure, got %v", err)
+	}
+
+	schematics.ReportAllErrors = false
+	if messages := schematics.Validate(data).GetStrings("en", "%target: %message"); messages == nil || len(*messages) != 2 {
+		t.Errorf("expected one message per field, got %v", messages)
+	}
+	if errs := schematics.Validate(data); len(errs.ErrorsOf("password")) != 1 {
+		t.Errorf("only the first failure should be collected, got %v", errs.ErrorsOf("password"))
+	}
+}
+
+func TestErrorsAddError(t *testing.T) {
+	var errs errorHandler.Errors
+	for _, validator := range []string{"IsString", "MinLengthAllowed"} {
+		err := errorHandler.Error{Validator: validator}
+		err.AddMessage("en", validator+" failed")
+		errs.AddError("name", err)
+	}
+	if got := errs.Messages["name"].Validator; got != "MinLengthAllowed" {
+		t.Errorf("the last error added to a target should replace the previous one, got %s", got)
+	}
+	if failures := errs.ErrorsOf("name"); len(failures) != 1 || failures[0].Validator != "MinLengthAllowed" {
+		t.Errorf("replaced errors should not be kept, got %v", failures)
+	}
+
+	var merged errorHandler.Errors
+	merged.AddError("name", errorHandler.Error{Validator: "IsRequired", Message: map[errorHandler.Locale]string{"en": "required"}})
+	merged.MergeErrors(&errs)
+	if got := merged.Messages["name"].Validator; got != "MinLengthAllowed" {
+		t.Errorf("merged errors should replace the errors of the target, got %s", got)
+	}
+}
+
+func TestV0NullAndEmptyValues(t *testing.T) {
+	var dMap utils.DataMap
+	dMap.FlattenTheMap(map[string]interface{}{
+		"object": map[string]interface{}{},
+		"array":  []interface{}{},
+		"null":   nil,
+		"items":  []interface{}{nil, map[string]interface{}{"empty": []interface{}{}}},
+	}, "", ".")
+	for _, key := range []string{"object", "array", "null", "items.0", "items.1.empty"} {
+		if _, exists := dMap.Data[key]; !exists {
+			t.Errorf("%s should be kept in the flat data", key)
+		}
+	}
+
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/nullable.json"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Fatal(errs.Error())
+	}
+
+	data := map[string]interface{}{
+		"nickname":    nil,
+		"middle_name": nil,
+		"email":       nil,
+		"tags":        []interface{}{},
+		"labels":      []interface{}{},
+		"title":       "",
+		"birthday":    nil,
+		"age":         nil,
+	}
+	expected := map[errorHandler.Target]string{
+		"middle_name": "Nullable",
+		"tags":        "ArrayLengthMin",
+		"title":       "AllowEmpty",
+	}
+	errs := schematics.Validate(data)
+	if errs == nil || len(errs.Messages) != len(expected) {
+		t.Fatalf("expected errors on %v, got %v", expected, errs)
+	}
+	for target, validator := range expected {
+		if got := errs.Messages[target].Validator; got != validator {
+			t.Errorf("%s: expected %s to fail, got %s", target, validator, got)
+		}
+	}
+
+	birthday := schematics.Schema.Fields["birthday"]
+	birthday.IsRequired = true
+	if err := birthday.Validate(nil, schematics.Validators.ValidationFns, nil); err == nil || err.Validator != "Required" {
+		t.Errorf("a required null date should be reported as required, got %v", err)
+	}
+
+	errs = schematics.Validate(map[string]interface{}{})
+	if errs == nil || len(errs.Messages) != 2 {
+		t.Fatalf("expected email and tags to be required, got %v", errs)
+	}
+	for _, target := range []errorHandler.Target{"email", "tags"} {
+		if _, exists := errs.Messages[target]; !exists {
+			t.Errorf("%s should be required when it is absent", target)
+		}
+	}
+
+	if errs := schematics.Validate(map[string]interface{}{"email": "a@b.c", "tags": []interface{}{"a"}}); errs != nil {
+		t.Errorf("expected no errors, got %v", errs.GetStrings("en", "%target: %message"))
+	}
+
+	operated := *schematics.OperateOnObject(map[string]interface{}{"tags": []interface{}{}, "meta": map[string]interface{}{}})
+	if _, exists := operated["tags"]; !exists {
+		t.Error("empty arrays should be kept by the operations")
+	}
+	if _, exists := operated["meta"]; !exists {
+		t.Error("empty objects should be kept by the operations")
+	}
+
+	converted := v2.FromV0(&schematics.Schema).ToV0()
+	if nickname := converted.Fields["nickname"]; nickname.Nullable == nil || !*nickname.Nullable {
+		t.Error("nullable should be kept by the conversions")
+	}
+	if title := converted.Fields["title"]; title.AllowEmpty == nil || *title.AllowEmpty {
+		t.Error("allow_empty should be kept by the conversions")
+	}
+
+	doc := schematics.ToJsonSchema()
+	nickname := doc["properties"].(map[string]interface{})["nickname"].(map[string]interface{})
+	if !reflect.DeepEqual(nickname["type"], []interface{}{"string", "null"}) {
+		t.Errorf("nullable fields should accept null in the json schema, got %v", nickname["type"])
+	}
+	var imported v0.Schematics
+	if _, err := imported.LoadJsonSchemaMap(doc); err != nil {
+		t.Fatal(err)
+	}
+	if field := imported.Schema.Fields["nickname"]; field.Nullable == nil || !*field.Nullable {
+		t.Error("nullable should be imported from the json schema")
+	}
+}
+
+func TestV0Defaults(t *testing.T) {
+	schematics := v0.Schematics{DefaultFns: map[string]v0.DefaultFn{
+		"$tenant": func() interface{} { return "acme" },
+	}}
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/defaults.json"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Fatal(errs.Error())
+	}
+
+	data := map[string]interface{}{
+		"status":            "done",
+		"shipping_required": true,
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a"},
+			map[string]interface{}{"sku": "b", "quantity": 3.0},
+			map[string]interface{}{},
+		},
+	}
+	result := *schematics.OperateOnObject(data)
+	expected := map[string]interface{}{
+		"status":   "done",
+		"retries":  0.0,
+		"tags":     []interface{}{},
+		"tenant":   "acme",
+		"shipping": map[string]interface{}{"method": "standard"},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a", "quantity": 1.0, "options": map[string]interface{}{"gift": false}},
+			map[string]interface{}{"sku": "b", "quantity": 3.0, "options": map[string]interface{}{"gift": false}},
+			map[string]interface{}{"quantity": 1.0, "options": map[string]interface{}{"gift": false}},
+		},
+	}
+	for key, value := range expected {
+		if !reflect.DeepEqual(result[key], value) {
+			t.Errorf("%s: expected %v, got %v", key, value, result[key])
+		}
+	}
+	for _, target := range []v0.TargetKey{"id", "created_at"} {
+		field := schematics.Schema.Fields[target]
+		if err := field.Validate(result[string(target)], schematics.Validators.ValidationFns, nil); err != nil {
+			t.Errorf("%s: dynamic default should be valid, got %v", target, err.Message)
+		}
+	}
+
+	again := *schematics.OperateOnObject(map[string]interface{}{})
+	if again["id"] == result["id"] {
+		t.Error("every document should get a new uuid")
+	}
+	if _, exists := again["shipping"]; exists {
+		t.Error("defaults should follow the when condition")
+	}
+	if _, exists := again["items"]; exists {
+		t.Error("wildcard defaults should not create arrays")
+	}
+
+	var invalid v0.Schematics
+	err := invalid.LoadMap(map[string]interface{}{
+		"version": "1",
+		"fields": map[string]interface{}{
+			"retries": map[string]interface{}{"default": "none", "validators": map[string]interface{}{"IsNumber": map[string]interface{}{}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := invalid.Compile(); !errs.HasErrors() || errs.Errors[0].Path != "default" {
+		t.Errorf("an invalid default should be reported, got %v", errs)
+	}
+}
+
+func TestV0Coerce(t *testing.T) {
+	schematics := v0.Schematics{Coerce: true}
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/coerce.json"); err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]interface{}{
+		"age":     "42",
+		"count":   "3",
+		"active":  "yes",
+		"since":   "1700000000",
+		"tags":    "a, b",
+		"min_age": "18",
+		"max_age": "65",
+	}
+	if errs := schematics.Validate(data); errs != nil {
+		t.Errorf("expected the strings to be converted, got %v", errs.GetStrings("en", "%target: %message"))
+	}
+
+	result := *schematics.OperateOnObject(data)
+	expected := map[string]interface{}{
+		"age":    42.0,
+		"count":  3.0,
+		"active": true,
+		"since":  "2023-11-14T22:13:20Z",
+		"tags":   []interface{}{"a", "b"},
+	}
+	for key, value := range expected {
+		if !reflect.DeepEqual(result[key], value) {
+			t.Errorf("%s: expected %v, got %v", key, value, result[key])
+		}
+	}
+
+	errs := schematics.Validate(map[string]interface{}{
+		"age":     "abc",
+		"count":   "3.5",
+		"active":  "maybe",
+		"tags":    "a,b,c",
+		"min_age": "65",
+		"max_age": "18",
+	})
+	failures := map[errorHandler.Target]string{
+		"age":     "Coerce",
+		"count":   "Coerce",
+		"active":  "Coerce",
+		"tags":    "ArrayLengthMax",
+		"max_age": "GreaterThanField",
+	}
+	if errs == nil || len(errs.Messages) != len(failures) {
+		t.Fatalf("expected errors on %v, got %v", failures, errs)
+	}
+	for target, validator := range failures {
+		if got := errs.Messages[target].Validator; got != validator {
+			t.Errorf("%s: expected %s to fail, got %s", target, validator, got)
+		}
+	}
+
+	schematics.Coerce = false
+	if errs := schematics.Validate(map[string]interface{}{"age": "42"}); errs == nil {
+		t.Error("strings should not be converted without coercion")
+	}
+
+	schema, err := apiv2.LoadOpenAPIFile("test-data/schema/openapi/example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/users?limit=20", nil)
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("request strings should not be converted unless the schema enables coercion")
+	}
+	if err := json.Unmarshal([]byte(`{"coerce": true}`), schema); err != nil || !schema.Coerce {
+		t.Fatalf("coerce should be read from the schema, got %v", err)
+	}
+	if errs := schema.ValidateRequest(r); errs.HasErrors() {
+		t.Error(errs.GetStrings("en", "%target: %message"))
+	}
+	r = httptest.NewRequest(http.MethodGet, "/users?limit=500", nil)
+	if errs := schema.ValidateRequest(r); !errs.HasErrors() {
+		t.Error("query parameters should be converted before the number validators")
+	}
+}
+
+func TestV0StrictMode(t *testing.T) {
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/strict.json"); err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]interface{}{
+		"emial":   "john@example.com",
+		"name":    "John",
+		"address": map[string]interface{}{"city": "Lahore", "zip": "54000"},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a", "qty": 1},
+			map[string]interface{}{"skuu": "b"},
+			map[string]interface{}{},
+		},
+		"tags": []interface{}{"a", "b"},
+		"meta": map[string]interface{}{"source": "import"},
+	}
+	unknown := func(errs *errorHandler.Errors) map[errorHandler.Target]string {
+		found := make(map[errorHandler.Target]string)
+		if errs == nil {
+			return found
+		}
+		for target, err := range errs.Messages {
+			if err.Validator == "UnknownKey" {
+				found[target] = err.Suggestion
+			}
+		}
+		return found
+	}
+
+	closed := map[errorHandler.Target]string{
+		"address.zip":  "",
+		"items.0.qty":  "",
+		"items.1.skuu": "items.1.sku",
+	}
+	if got := unknown(schematics.Validate(data)); !reflect.DeepEqual(got, closed) {
+		t.Errorf("closed fields should report their unknown keys, expected %v, got %v", closed, got)
+	}
+
+	schematics.Strict = true
+	strict := map[errorHandler.Target]string{"emial": "email"}
+	for target, suggestion := range closed {
+		strict[target] = suggestion
+	}
+	errs := schematics.Validate(data)
+	if got := unknown(errs); !reflect.DeepEqual(got, strict) {
+		t.Errorf("strict mode should report every unknown key, expected %v, got %v", strict, got)
+	}
+	if message := errs.Messages["emial"].Message["en"]; message != "this key is not allowed, did you mean email?" {
+		t.Errorf("unexpected message %q", message)
+	}
+
+	schematics.StripUnknown = true
+	result := *schematics.OperateOnObject(data)
+	expected := map[string]interface{}{
+		"name":    "John",
+		"address": map[string]interface{}{"city": "Lahore"},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a"},
+			map[string]interface{}{},
+			map[string]interface{}{},
+		},
+		"tags": []interface{}{"a", "b"},
+		"meta": map[string]interface{}{"source": "import"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected the unknown keys to be removed, got %v", result)
+	}
+
+	doc := schematics.ToJsonSchema()
+	address := doc["properties"].(map[string]interface{})["address"].(map[string]interface{})
+	if address["additionalProperties"] != false {
+		t.Errorf("closed fields should not allow additional properties, got %v", address)
+	}
+	var imported v0.Schematics
+	if _, err := imported.LoadJsonSchemaMap(doc); err != nil {
+		t.Fatal(err)
+	}
+	if !imported.Schema.Fields["address"].Closed {
+		t.Error("additionalProperties should be imported as closed")
+	}
+}
+
+func TestV0ContainerValidators(t *testing.T) {
+	var schematics v0.Schematics
+	if err := schematics.LoadSchemaFile("test-data/schema/direct/v0/containers.json"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		t.Fatal(errs.Error())
+	}
+
+	valid := map[string]interface{}{
+		"tags":     []interface{}{"abc", "def"},
+		"items":    []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "b"}},
+		"roles":    []interface{}{"admin", "member"},
+		"metadata": map[string]interface{}{"source": "import"},
+		"orders": []interface{}{
+			map[string]interface{}{"lines": []interface{}{map[string]interface{}{"quantity": 1}}},
+		},
+	}
+	if errs := schematics.Validate(valid); errs != nil {
+		t.Errorf("expected no errors, got %v", errs.GetStrings("en", "%target: %message"))
+	}
+
+	invalid := map[string]interface{}{
+		"tags":     []interface{}{"abc", "abc", "de"},
+		"items":    []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "a"}},
+		"roles":    []interface{}{"admin"},
+		"metadata": map[string]interface{}{"a": 1, "b": 2, "c": 3},
+		"orders": []interface{}{
+			map[string]interface{}{"lines": []interface{}{"x"}},
+			map[string]interface{}{"lines": []interface{}{}},
+		},
+	}
+	expected := map[errorHandler.Target]string{
+		"tags":           "ArrayUniqueItems",
+		"tags.2":         "MinLengthAllowed",
+		"items":          "ArrayUniqueItems",
+		"roles":          "ArrayContains",
+		"metadata":       "ObjectPropertiesMax",
+		"orders.1.lines": "ArrayLengthMin",
+	}
+	errs := schematics.Validate(invalid)
+	if errs == nil || len(errs.Messages) != len(expected) {
+		t.Fatalf("expected errors on %v, got %v", expected, errs)
+	}
+	for target, validator := range expected {
+		if got := errs.Messages[target].Validator; got != validator {
+			t.Errorf("%s: expected %s to fail, got %s", target, validator, got)
+		}
+	}
+
+	invalid["tags"] = []interface{}{"abc", "def", "ghi", "jkl"}
+	if errs := schematics.Validate(invalid); errs.Messages["tags"].Validator != "ArrayLengthMax" {
+		t.Errorf("the length of the array should be validated, got %v", errs.Messages["tags"])
+	}
+
+	// objects keyed by numeric ids stay objects
+	numeric := map[string]interface{}{"metadata": map[string]interface{}{"1": "a", "2": "b"}}
+	if errs := schematics.Validate(numeric); errs.HasErrors() {
+		t.Errorf("expected no errors, got %v", errs.GetStrings("en", "%target: %message"))
+	}
+	numeric["metadata"] = map[string]interface{}{"0": "a", "1": "b", "2": "c"}
+	if errs := schematics.Validate(numeric); !strings.Contains(errs.Messages["metadata"].Message["en"], "more than 2 properties") {
+		t.Errorf("an object with numeric keys should be validated as an object, got %v", errs.Messages["metadata"])
+	}
+
+	doc := schematics.ToJsonSchema()
+	properties := doc["properties"].(map[string]interface{})
+	if tags := properties["tags"].(map[string]interface{}); tags["uniqueItems"] != true || tags["maxItems"] != 3.0 {
+		t.Errorf("unexpected json schema for tags %v", tags)
+	}
+	if metadata := properties["metadata"].(map[string]interface{}); metadata["maxProperties"] != 2.0 {
+		t.Errorf("unexpected json schema for metadata %v", metadata)
+	}
+	var imported v0.Schematics
+	if _, err := imported.LoadJsonSchemaMap(doc); err != nil {
+		t.Fatal(err)
+	}
+	roles := imported.Schema.Fields["roles"]
+	if contains, exists := roles.Validators["ArrayContains"]; !exists || contains.Attributes["value"] != "member" {
+		t.Errorf("contains should be imported, got %v", roles.Validators)
+	}
+}
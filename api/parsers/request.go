@@ -2,33 +2,39 @@ package parsers
 
 import (
 	"encoding/json"
-	"github.com/ashbeelghouri/jsonschematics"
+	"github.com/ashbeelghouri/jsonschematics/utils"
 	"io"
 	"net/http"
 	"strings"
 )
 
 func ParseRequest(r *http.Request) (map[string]interface{}, error) {
-	var headers map[string]string
+	headers := map[string]string{}
 	for key, values := range r.Header {
 		headers[key] = values[0]
 	}
-	var body map[string]interface{}
+	body := map[string]interface{}{}
 	if r.Body != nil {
 		bodyBytes, err := io.ReadAll(r.Body)
 		if err != nil {
 			return nil, err
 		}
-		err = json.Unmarshal(bodyBytes, &body)
-		if err != nil {
-			return nil, err
+		if len(bodyBytes) > 0 {
+			err = json.Unmarshal(bodyBytes, &body)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
-	body = jsonschematics.DeflateMap(body, ".")
-	splitPath := strings.Split(r.RequestURI, "?")
+	body = utils.DeflateMap(body, ".")
+	requestURI := r.RequestURI
+	if requestURI == "" && r.URL != nil {
+		requestURI = r.URL.RequestURI()
+	}
+	splitPath := strings.SplitN(requestURI, "?", 2)
 	// get query parameters
 	query := map[string]interface{}{}
-	if splitPath[1] != "" {
+	if len(splitPath) > 1 && splitPath[1] != "" {
 		for _, param := range strings.Split(splitPath[1], "&") {
 			kv := strings.Split(param, "=")
 			if len(kv) == 2 {
@@ -1,11 +1,15 @@
 package v0
 
 import (
+	"fmt"
 	"github.com/ashbeelghouri/jsonschematics/api/parsers"
 	jsonschematics "github.com/ashbeelghouri/jsonschematics/data/v0"
 	"github.com/ashbeelghouri/jsonschematics/errorHandler"
 	"github.com/ashbeelghouri/jsonschematics/utils"
+	"io"
+	"io/fs"
 	"net/http"
+	"os"
 	"regexp"
 	"strings"
 )
@@ -15,48 +19,56 @@ type EndpointKey string
 type Name string
 
 type Field struct {
-	DependsOn  []string
-	Name       string
-	Type       string
-	Required   bool
-	Validators map[TargetKey]Constant
-	Operators  map[TargetKey]Constant
-	L10n       map[string]interface{}
+	DependsOn  []string               `json:"depends_on,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Type       string                 `json:"type,omitempty"`
+	Required   bool                   `json:"required,omitempty"`
+	Validators map[TargetKey]Constant `json:"validators,omitempty"`
+	// ValidatorOrder is the order in which the validators are declared, it is read from the schema file
+	ValidatorOrder        []string               `json:"-"`
+	Operators             map[TargetKey]Constant `json:"operators,omitempty"`
+	L10n                  map[string]interface{} `json:"l10n,omitempty"`
+	AdditionalInformation map[string]interface{} `json:"additional_information,omitempty"`
 }
 
 type Constant struct {
-	Attributes map[string]interface{} `json:"attributes"`
-	ErrMsg     string                 `json:"error"`
-	L10n       map[string]interface{} `json:"l10n"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	ErrMsg     string                 `json:"error,omitempty"`
+	L10n       map[string]interface{} `json:"l10n,omitempty"`
+	Priority   int                    `json:"priority,omitempty"`
 }
 
 type Global struct {
-	Headers map[TargetKey]Field
+	Headers map[TargetKey]Field `json:"headers,omitempty"`
 }
 
 type Endpoint struct {
-	Type    string
-	Body    map[TargetKey]Field
-	Headers map[TargetKey]Field
-	Query   map[TargetKey]Field
+	Path    string              `json:"path,omitempty"`
+	Type    string              `json:"type"`
+	Body    map[TargetKey]Field `json:"body,omitempty"`
+	Headers map[TargetKey]Field `json:"headers,omitempty"`
+	Query   map[TargetKey]Field `json:"query,omitempty"`
+	Params  map[TargetKey]Field `json:"params,omitempty"`
 }
 
 type Schema struct {
-	Version   string
-	Global    Global
-	Locale    string
-	Logger    utils.Logger
-	Endpoints map[EndpointKey]Endpoint
+	Version   string                   `json:"version"`
+	Global    Global                   `json:"global"`
+	Locale    string                   `json:"locale,omitempty"`
+	Logger    utils.Logger             `json:"-"`
+	Endpoints map[EndpointKey]Endpoint `json:"endpoints"`
+	// Coerce converts the path parameters, the headers and the query parameters to the type of their field,
+	// the body is json and keeps its types
+	Coerce bool `json:"coerce,omitempty"`
 }
 
 func (s *Schema) GetSchematics(fieldType string, fields *map[TargetKey]Field) (*jsonschematics.Schematics, error) {
 	var schematics jsonschematics.Schematics
-	FieldKeys := jsonschematics.Field{
-		DependsOn:  []string{},
-		Type:       fieldType,
-		Validators: map[string]jsonschematics.Constant{},
-		Operators:  map[string]jsonschematics.Constant{},
-	}
+	schematics.Logging = s.Logger
+	schematics.Locale = s.Locale
+	schematics.Separator = "."
+	schematics.Validators.BasicValidators()
+	schematics.Operators.LoadBasicOperations()
 
 	schema := jsonschematics.Schema{
 		Version: s.Version,
@@ -64,16 +76,16 @@ func (s *Schema) GetSchematics(fieldType string, fields *map[TargetKey]Field) (*
 	}
 
 	for target, f := range *fields {
-		var allValidators map[string]jsonschematics.Constant
-
+		allValidators := make(map[string]jsonschematics.Constant)
 		for key, validator := range f.Validators {
 			allValidators[string(key)] = jsonschematics.Constant{
 				Attributes: validator.Attributes,
 				Error:      validator.ErrMsg,
 				L10n:       validator.L10n,
+				Priority:   validator.Priority,
 			}
 		}
-		var allOperations map[string]jsonschematics.Constant
+		allOperations := make(map[string]jsonschematics.Constant)
 		for key, operator := range f.Operators {
 			allOperations[string(key)] = jsonschematics.Constant{
 				Attributes: operator.Attributes,
@@ -81,11 +93,19 @@ func (s *Schema) GetSchematics(fieldType string, fields *map[TargetKey]Field) (*
 				L10n:       operator.L10n,
 			}
 		}
-		FieldKeys.Type = f.Type
-		FieldKeys.Validators = allValidators
-		FieldKeys.Operators = allOperations
-		FieldKeys.L10n = s.Global.Headers[target].L10n
-		schema.Fields[jsonschematics.TargetKey(target)] = FieldKeys
+		field := jsonschematics.Field{
+			DependsOn:             f.DependsOn,
+			Name:                  f.Name,
+			Type:                  f.Type,
+			IsRequired:            f.Required,
+			Validators:            allValidators,
+			ValidatorOrder:        f.ValidatorOrder,
+			Operators:             allOperations,
+			L10n:                  f.L10n,
+			AdditionalInformation: f.AdditionalInformation,
+		}
+		field.IsRequired = field.Required()
+		schema.Fields[jsonschematics.TargetKey(target)] = field
 	}
 
 	schematics.Schema = schema
@@ -114,26 +134,46 @@ func (s *Schema) ValidateRequest(r *http.Request) *errorHandler.Errors {
 		errorMessages.AddError(internalErrors, errMsg)
 		return &errorMessages
 	}
+	globalHeadersSchematics.Coerce = s.Coerce
 	errs := globalHeadersSchematics.Validate(transformedRequest["headers"])
 	if errs.HasErrors() {
-		s.Logger.ERROR("all errors", err.Error())
+		s.Logger.ERROR("validation errors on global headers:", errs.GetStrings("en", "%validator: %message"))
 		return errs
 	}
 
-	for path, endpoint := range s.Endpoints {
-		regex := utils.GetPathRegex(string(path))
+	for key, endpoint := range s.Endpoints {
+		path := endpoint.Path
+		if path == "" {
+			path = string(key)
+		}
+		regex := utils.GetPathRegex(path)
 		matched, err := regexp.MatchString(regex, transformedRequest["path"].(string))
 		if err != nil {
 			errMsg.AddMessage("en", "path not matched - regex not matched")
 			errorMessages.AddError(internalErrors, errMsg)
 			return &errorMessages
 		}
-		if matched {
-			s.Logger.DEBUG("url not matched")
-			return nil
+		if !matched {
+			s.Logger.DEBUG("url not matched", path)
+			continue
 		}
 
 		if strings.ToLower(endpoint.Type) == strings.ToLower(transformedRequest["method"].(string)) {
+			if len(endpoint.Params) > 0 {
+				paramSchematics, err := s.GetSchematics("Params", &endpoint.Params)
+				if err != nil {
+					s.Logger.ERROR(err.Error())
+					errMsg.AddMessage("en", err.Error())
+					errorMessages.AddError(internalErrors, errMsg)
+					return &errorMessages
+				}
+				paramSchematics.Coerce = s.Coerce
+				errs := paramSchematics.Validate(utils.GetPathParams(path, transformedRequest["path"].(string)))
+				if errs.HasErrors() {
+					s.Logger.ERROR("validation errors on params:", errs.GetStrings("en", "%validator: %message"))
+					return errs
+				}
+			}
 			headerSchematics, err := s.GetSchematics("Headers", &endpoint.Headers)
 			if err != nil {
 				s.Logger.ERROR(err.Error())
@@ -141,6 +181,7 @@ func (s *Schema) ValidateRequest(r *http.Request) *errorHandler.Errors {
 				errorMessages.AddError(internalErrors, errMsg)
 				return &errorMessages
 			}
+			headerSchematics.Coerce = s.Coerce
 			errs := headerSchematics.Validate(transformedRequest["headers"])
 			if errs.HasErrors() {
 				s.Logger.ERROR("validation errors on headers:", errs.GetStrings("en", "%validator: %message"))
@@ -165,6 +206,7 @@ func (s *Schema) ValidateRequest(r *http.Request) *errorHandler.Errors {
 				errorMessages.AddError(internalErrors, errMsg)
 				return &errorMessages
 			}
+			querySchematics.Coerce = s.Coerce
 			errs = querySchematics.Validate(transformedRequest["query"])
 			if errs.HasErrors() {
 				s.Logger.ERROR("validation errors on query:", errs.GetStrings("en", "%validator: %message"))
@@ -174,3 +216,100 @@ func (s *Schema) ValidateRequest(r *http.Request) *errorHandler.Errors {
 	}
 	return nil
 }
+
+// LoadSchemaFile detects the format of the schema file from the extension or the content
+func (s *Schema) LoadSchemaFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		s.Logger.ERROR("Failed to load schema file", err)
+		return err
+	}
+	return s.loadSchema(path, content, "")
+}
+
+// LoadSchemaFS loads the schema file from the file system, for example an embed.FS
+func (s *Schema) LoadSchemaFS(fsys fs.FS, name string) error {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		s.Logger.ERROR("Failed to load schema file", err)
+		return err
+	}
+	return s.loadSchema(name, content, "")
+}
+
+// LoadSchemaReader reads a json or yaml schema, the format is detected from the content when it is empty
+func (s *Schema) LoadSchemaReader(r io.Reader, format string) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		s.Logger.ERROR("Failed to read schema", err)
+		return err
+	}
+	return s.loadSchema("", content, format)
+}
+
+func (s *Schema) loadSchema(path string, content []byte, format string) error {
+	if format == "" {
+		format = utils.DetectFormat(path, content)
+	}
+	err := utils.UnmarshalSchema(content, format, s)
+	if err != nil {
+		s.Logger.ERROR("Failed to unmarshall schema file", err)
+		return err
+	}
+	order, err := utils.ReadKeyOrder(content, format)
+	if err != nil {
+		s.Logger.ERROR("Failed to unmarshall schema file", err)
+		return err
+	}
+	s.applyKeyOrder(order)
+	return nil
+}
+
+// applyKeyOrder keeps the order of the validators of the headers and the endpoints
+func (s *Schema) applyKeyOrder(order utils.KeyOrder) {
+	applyFieldsOrder(order, s.Global.Headers, "global", "headers")
+	for key, endpoint := range s.Endpoints {
+		applyFieldsOrder(order, endpoint.Headers, "endpoints", string(key), "headers")
+		applyFieldsOrder(order, endpoint.Body, "endpoints", string(key), "body")
+		applyFieldsOrder(order, endpoint.Query, "endpoints", string(key), "query")
+		applyFieldsOrder(order, endpoint.Params, "endpoints", string(key), "params")
+	}
+}
+
+func applyFieldsOrder(order utils.KeyOrder, fields map[TargetKey]Field, path ...string) {
+	for target, field := range fields {
+		field.ValidatorOrder = order.Keys(append(path, string(target), "validators")...)
+		fields[target] = field
+	}
+}
+
+// MarshalJSON writes the validators in the order they are declared
+func (f Field) MarshalJSON() ([]byte, error) {
+	type plain Field
+	return utils.MarshalJSON(struct {
+		plain
+		Validators utils.OrderedObject `json:"validators,omitempty"`
+	}{plain(f), utils.NewOrderedObject(f.Validators, f.ValidatorOrder)})
+}
+
+// LoadSchemasFS loads every file of the file system matching the glob pattern, keyed by the path of the file
+func LoadSchemasFS(fsys fs.FS, pattern string) (map[string]*Schema, error) {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*Schema)
+	for _, name := range names {
+		var schema Schema
+		if err := schema.LoadSchemaFS(fsys, name); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		schemas[name] = &schema
+	}
+	return schemas, nil
+}
+
+// Export writes the schema as pretty printed json or yaml
+func (s *Schema) Export(format string) ([]byte, error) {
+	return utils.MarshalSchema(s, format)
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	basic "github.com/ashbeelghouri/jsonschematics/api/v0"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"sort"
+	"strings"
+)
+
+// FromV0 converts the base schema into the v1 format, the fields are sorted by the key and the validators keep their order,
+// required fields get the IsRequired validator as the v1 format has no required flag
+func FromV0(schema *basic.Schema) *Schema {
+	converted := Schema{
+		Version:   schema.Version,
+		Global:    Global{Headers: fieldsFromV0(schema.Global.Headers)},
+		Endpoints: make(map[string]Endpoint),
+		Locale:    schema.Locale,
+		Logger:    schema.Logger,
+		Coerce:    schema.Coerce,
+	}
+	for key, endpoint := range schema.Endpoints {
+		converted.Endpoints[string(key)] = Endpoint{
+			Path:    endpoint.Path,
+			Type:    endpoint.Type,
+			Body:    fieldsFromV0(endpoint.Body),
+			Headers: fieldsFromV0(endpoint.Headers),
+			Query:   fieldsFromV0(endpoint.Query),
+			Params:  fieldsFromV0(endpoint.Params),
+		}
+	}
+	return &converted
+}
+
+func (s *Schema) ToV0() *basic.Schema {
+	return s.transformTov0()
+}
+
+// Export writes the schema as pretty printed json or yaml
+func (s *Schema) Export(format string) ([]byte, error) {
+	return utils.MarshalSchema(s, format)
+}
+
+func fieldsFromV0(fields map[basic.TargetKey]basic.Field) []Field {
+	var keys []string
+	for key := range fields {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	var converted []Field
+	for _, key := range keys {
+		field := fields[basic.TargetKey(key)]
+		validators := constantsFromV0(field.Validators)
+		if field.Required && !hasRequiredValidator(validators) {
+			if validators == nil {
+				validators = make(map[string]Constant)
+			}
+			validators["IsRequired"] = Constant{}
+		}
+		converted = append(converted, Field{
+			DependsOn:             field.DependsOn,
+			Type:                  field.Type,
+			Key:                   key,
+			Validators:            validators,
+			ValidatorOrder:        field.ValidatorOrder,
+			Operators:             constantsFromV0(field.Operators),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
+		})
+	}
+	return converted
+}
+
+// MarshalJSON writes the validators in the order they are declared
+func (f Field) MarshalJSON() ([]byte, error) {
+	type plain Field
+	return utils.MarshalJSON(struct {
+		plain
+		Validators utils.OrderedObject `json:"validators,omitempty"`
+	}{plain(f), utils.NewOrderedObject(f.Validators, f.ValidatorOrder)})
+}
+
+func constantsFromV0(constants map[basic.TargetKey]basic.Constant) map[string]Constant {
+	if len(constants) == 0 {
+		return nil
+	}
+	converted := make(map[string]Constant, len(constants))
+	for name, c := range constants {
+		converted[string(name)] = Constant{
+			Attributes: c.Attributes,
+			ErrMsg:     c.ErrMsg,
+			Priority:   c.Priority,
+			L10n:       c.L10n,
+		}
+	}
+	return converted
+}
+
+func hasRequiredValidator(validators map[string]Constant) bool {
+	for name := range validators {
+		if utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,14 @@ package v1
 
 import (
 	"encoding/json"
+	"fmt"
 	basic "github.com/ashbeelghouri/jsonschematics/api/v0"
 	"github.com/ashbeelghouri/jsonschematics/utils"
+	"io"
+	"io/fs"
 	"log"
 	"os"
+	"strconv"
 )
 
 var Logs utils.Logger
@@ -14,35 +18,59 @@ type Schema struct {
 	Version   string              `json:"version"`
 	Global    Global              `json:"global"`
 	Endpoints map[string]Endpoint `json:"endpoints"`
-	Locale    string
-	Logger    utils.Logger
+	Locale    string              `json:"locale,omitempty"`
+	Logger    utils.Logger        `json:"-"`
+	// Coerce converts the path parameters, the headers and the query parameters to the type of their field
+	Coerce bool `json:"coerce,omitempty"`
 }
 
 type Global struct {
-	Headers []Field `json:"headers"`
+	Headers []Field `json:"headers,omitempty"`
 }
 
 type Endpoint struct {
-	Path    string  `json:"path"`
+	Path    string  `json:"path,omitempty"`
 	Type    string  `json:"type"`
-	Body    []Field `json:"body"`
-	Headers []Field `json:"headers"`
-	Query   []Field `json:"query"`
+	Body    []Field `json:"body,omitempty"`
+	Headers []Field `json:"headers,omitempty"`
+	Query   []Field `json:"query,omitempty"`
+	Params  []Field `json:"params,omitempty"`
 }
 
 type Field struct {
-	DependsOn             []string
-	Key                   string                 `json:"target_key"`
-	Validators            map[string]Constant    `json:"validators"`
-	Operators             map[string]Constant    `json:"operators"`
-	L10n                  map[string]interface{} `json:"l10n"`
-	AdditionalInformation map[string]interface{} `json:"additional_information"`
+	DependsOn  []string            `json:"depends_on,omitempty"`
+	Key        string              `json:"target_key"`
+	Type       string              `json:"type,omitempty"`
+	Validators map[string]Constant `json:"validators,omitempty"`
+	// ValidatorOrder is the order in which the validators are declared, it is read from the schema file
+	ValidatorOrder        []string               `json:"-"`
+	Operators             map[string]Constant    `json:"operators,omitempty"`
+	L10n                  map[string]interface{} `json:"l10n,omitempty"`
+	AdditionalInformation map[string]interface{} `json:"additional_information,omitempty"`
 }
 
 type Constant struct {
-	Attributes map[string]interface{} `json:"attributes"`
-	ErrMsg     string                 `json:"error"`
-	L10n       map[string]interface{} `json:"l10n"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	ErrMsg     string                 `json:"error,omitempty"`
+	L10n       map[string]interface{} `json:"l10n,omitempty"`
+	Priority   int                    `json:"priority,omitempty"`
+}
+
+// UnmarshalJSON also reads the DependsOn key of the files written before depends_on
+func (f *Field) UnmarshalJSON(content []byte) error {
+	type plain Field
+	var field struct {
+		plain
+		LegacyDependsOn []string `json:"DependsOn"`
+	}
+	if err := json.Unmarshal(content, &field); err != nil {
+		return err
+	}
+	*f = Field(field.plain)
+	if f.DependsOn == nil {
+		f.DependsOn = field.LegacyDependsOn
+	}
+	return nil
 }
 
 func (s *Schema) Configs() {
@@ -56,22 +84,85 @@ func (s *Schema) Configs() {
 }
 
 func LoadJsonSchemaFile(path string) (*basic.Schema, error) {
-	var schema Schema
-	schema.Configs()
+	return loadSchemaFile(path, utils.FormatJson)
+}
+
+func LoadYamlSchemaFile(path string) (*basic.Schema, error) {
+	return loadSchemaFile(path, utils.FormatYaml)
+}
+
+func LoadSchemaFile(path string) (*basic.Schema, error) {
+	return loadSchemaFile(path, "")
+}
+
+func loadSchemaFile(path string, format string) (*basic.Schema, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		Logs.ERROR("Failed to load schema file", err)
 		return nil, err
 	}
-	err = json.Unmarshal(content, &schema)
+	return loadSchema(path, content, format)
+}
+
+// LoadSchemaFS loads the schema file from the file system, for example an embed.FS
+func LoadSchemaFS(fsys fs.FS, name string) (*basic.Schema, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		Logs.ERROR("Failed to load schema file", err)
+		return nil, err
+	}
+	return loadSchema(name, content, "")
+}
+
+// LoadSchemaReader reads a json or yaml schema, the format is detected from the content when it is empty
+func LoadSchemaReader(r io.Reader, format string) (*basic.Schema, error) {
+	content, err := io.ReadAll(r)
 	if err != nil {
+		Logs.ERROR("Failed to read schema", err)
 		return nil, err
 	}
+	return loadSchema("", content, format)
+}
+
+// LoadSchemasFS loads every file of the file system matching the glob pattern, keyed by the path of the file
+func LoadSchemasFS(fsys fs.FS, pattern string) (map[string]*basic.Schema, error) {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*basic.Schema)
+	for _, name := range names {
+		schema, err := LoadSchemaFS(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+func loadSchema(path string, content []byte, format string) (*basic.Schema, error) {
+	var schema Schema
+	schema.Configs()
+	if format == "" {
+		format = utils.DetectFormat(path, content)
+	}
+	err := utils.UnmarshalSchema(content, format, &schema)
+	if err != nil {
+		Logs.ERROR("Failed to unmarshall schema file", err)
+		return nil, err
+	}
+	order, err := utils.ReadKeyOrder(content, format)
+	if err != nil {
+		Logs.ERROR("Failed to unmarshall schema file", err)
+		return nil, err
+	}
+	schema.applyKeyOrder(order)
 	return schema.transformTov0(), nil
 }
 
 func LoadMap(schemaMap interface{}) (*basic.Schema, error) {
-	var s *Schema
+	var s Schema
 	s.Configs()
 	jsonBytes, err := json.Marshal(schemaMap)
 	if err != nil {
@@ -85,12 +176,30 @@ func LoadMap(schemaMap interface{}) (*basic.Schema, error) {
 	return s.transformTov0(), nil
 }
 
+// applyKeyOrder keeps the order of the validators of the headers and the endpoints
+func (s *Schema) applyKeyOrder(order utils.KeyOrder) {
+	applyFieldsOrder(order, s.Global.Headers, "global", "headers")
+	for key, endpoint := range s.Endpoints {
+		applyFieldsOrder(order, endpoint.Headers, "endpoints", key, "headers")
+		applyFieldsOrder(order, endpoint.Body, "endpoints", key, "body")
+		applyFieldsOrder(order, endpoint.Query, "endpoints", key, "query")
+		applyFieldsOrder(order, endpoint.Params, "endpoints", key, "params")
+	}
+}
+
+func applyFieldsOrder(order utils.KeyOrder, fields []Field, path ...string) {
+	for i := range fields {
+		fields[i].ValidatorOrder = order.Keys(append(path, strconv.Itoa(i), "validators")...)
+	}
+}
+
 func transformComponents(components map[string]Constant) map[basic.TargetKey]basic.Constant {
 	results := map[basic.TargetKey]basic.Constant{}
 	for key, constant := range components {
 		results[basic.TargetKey(key)] = basic.Constant{
 			Attributes: constant.Attributes,
 			ErrMsg:     constant.ErrMsg,
+			Priority:   constant.Priority,
 			L10n:       constant.L10n,
 		}
 	}
@@ -101,16 +210,20 @@ func (s *Schema) transformTov0() *basic.Schema {
 	var baseSchema basic.Schema
 	baseSchema.Version = s.Version
 	baseSchema.Locale = s.Locale
+	baseSchema.Coerce = s.Coerce
 	baseSchema.Logger = s.Logger
 	global := basic.Global{Headers: map[basic.TargetKey]basic.Field{}}
 	endpoints := map[basic.EndpointKey]basic.Endpoint{}
 
 	for _, field := range s.Global.Headers {
 		global.Headers[basic.TargetKey(field.Key)] = basic.Field{
-			DependsOn:  field.DependsOn,
-			Validators: transformComponents(field.Validators),
-			Operators:  transformComponents(field.Operators),
-			L10n:       field.L10n,
+			DependsOn:             field.DependsOn,
+			Type:                  field.Type,
+			Validators:            transformComponents(field.Validators),
+			ValidatorOrder:        field.ValidatorOrder,
+			Operators:             transformComponents(field.Operators),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
 		}
 	}
 
@@ -118,37 +231,61 @@ func (s *Schema) transformTov0() *basic.Schema {
 		headers := map[basic.TargetKey]basic.Field{}
 		for _, field := range endpoint.Headers {
 			headers[basic.TargetKey(field.Key)] = basic.Field{
-				DependsOn:  field.DependsOn,
-				Validators: transformComponents(field.Validators),
-				Operators:  transformComponents(field.Operators),
-				L10n:       field.L10n,
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        field.ValidatorOrder,
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
 			}
 		}
 		body := map[basic.TargetKey]basic.Field{}
 		for _, field := range endpoint.Body {
 			body[basic.TargetKey(field.Key)] = basic.Field{
-				DependsOn:  field.DependsOn,
-				Validators: transformComponents(field.Validators),
-				Operators:  transformComponents(field.Operators),
-				L10n:       field.L10n,
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        field.ValidatorOrder,
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
 			}
 		}
 
 		query := map[basic.TargetKey]basic.Field{}
-		for _, field := range endpoint.Body {
+		for _, field := range endpoint.Query {
 			query[basic.TargetKey(field.Key)] = basic.Field{
-				DependsOn:  field.DependsOn,
-				Validators: transformComponents(field.Validators),
-				Operators:  transformComponents(field.Operators),
-				L10n:       field.L10n,
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        field.ValidatorOrder,
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
+			}
+		}
+
+		params := map[basic.TargetKey]basic.Field{}
+		for _, field := range endpoint.Params {
+			params[basic.TargetKey(field.Key)] = basic.Field{
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        field.ValidatorOrder,
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
 			}
 		}
 
 		endpoints[basic.EndpointKey(path)] = basic.Endpoint{
+			Path:    endpoint.Path,
 			Type:    endpoint.Type,
 			Body:    body,
 			Headers: headers,
 			Query:   query,
+			Params:  params,
 		}
 	}
 
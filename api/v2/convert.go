@@ -0,0 +1,136 @@
+package v2
+
+import (
+	basic "github.com/ashbeelghouri/jsonschematics/api/v0"
+	v1 "github.com/ashbeelghouri/jsonschematics/api/v1"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+)
+
+// FromV0 converts the base schema into the v2 format, the fields are sorted by the key,
+// the validators keep the order they are declared in and the operators are sorted by their name
+func FromV0(schema *basic.Schema) *Schema {
+	return FromV1(v1.FromV0(schema))
+}
+
+func (s *Schema) ToV0() *basic.Schema {
+	return s.transformTov0()
+}
+
+func FromV1(schema *v1.Schema) *Schema {
+	converted := Schema{
+		Version:   schema.Version,
+		Global:    Global{Headers: fieldsFromV1(schema.Global.Headers)},
+		Endpoints: make(map[string]Endpoint),
+		Locale:    schema.Locale,
+		Logger:    schema.Logger,
+		Coerce:    schema.Coerce,
+	}
+	for key, endpoint := range schema.Endpoints {
+		converted.Endpoints[key] = Endpoint{
+			Path:    endpoint.Path,
+			Type:    endpoint.Type,
+			Body:    fieldsFromV1(endpoint.Body),
+			Headers: fieldsFromV1(endpoint.Headers),
+			Query:   fieldsFromV1(endpoint.Query),
+			Params:  fieldsFromV1(endpoint.Params),
+		}
+	}
+	return &converted
+}
+
+// ToV1 converts the schema into the v1 format, when a validator or an operator
+// is listed more than once the last one is kept
+func (s *Schema) ToV1() *v1.Schema {
+	converted := v1.Schema{
+		Version:   s.Version,
+		Global:    v1.Global{Headers: fieldsToV1(s.Global.Headers)},
+		Endpoints: make(map[string]v1.Endpoint),
+		Locale:    s.Locale,
+		Logger:    s.Logger,
+		Coerce:    s.Coerce,
+	}
+	for key, endpoint := range s.Endpoints {
+		converted.Endpoints[key] = v1.Endpoint{
+			Path:    endpoint.Path,
+			Type:    endpoint.Type,
+			Body:    fieldsToV1(endpoint.Body),
+			Headers: fieldsToV1(endpoint.Headers),
+			Query:   fieldsToV1(endpoint.Query),
+			Params:  fieldsToV1(endpoint.Params),
+		}
+	}
+	return &converted
+}
+
+// Export writes the schema as pretty printed json or yaml
+func (s *Schema) Export(format string) ([]byte, error) {
+	return utils.MarshalSchema(s, format)
+}
+
+func fieldsFromV1(fields []v1.Field) []Field {
+	var converted []Field
+	for _, field := range fields {
+		converted = append(converted, Field{
+			DependsOn:             field.DependsOn,
+			Type:                  field.Type,
+			Key:                   field.Key,
+			Validators:            componentsFromV1(field.Validators, field.ValidatorOrder),
+			Operators:             componentsFromV1(field.Operators, nil),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
+		})
+	}
+	return converted
+}
+
+func fieldsToV1(fields []Field) []v1.Field {
+	var converted []v1.Field
+	for _, field := range fields {
+		converted = append(converted, v1.Field{
+			DependsOn:             field.DependsOn,
+			Type:                  field.Type,
+			Key:                   field.Key,
+			Validators:            componentsToV1(field.Validators),
+			ValidatorOrder:        componentNames(field.Validators),
+			Operators:             componentsToV1(field.Operators),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
+		})
+	}
+	return converted
+}
+
+// componentsFromV1 lists the components in the order, the components missing from the order are sorted by their name
+func componentsFromV1(constants map[string]v1.Constant, order []string) []Component {
+	names := make([]string, 0, len(constants))
+	for name := range constants {
+		names = append(names, name)
+	}
+	var components []Component
+	for _, name := range utils.OrderKeys(order, names) {
+		components = append(components, Component{
+			Name:       name,
+			Attributes: constants[name].Attributes,
+			ErrMsg:     constants[name].ErrMsg,
+			Priority:   constants[name].Priority,
+			L10n:       constants[name].L10n,
+		})
+	}
+	return components
+}
+
+func componentsToV1(components []Component) map[string]v1.Constant {
+	if len(components) == 0 {
+		return nil
+	}
+	constants := make(map[string]v1.Constant, len(components))
+	for _, c := range components {
+		constants[c.Name] = v1.Constant{
+			Attributes: c.Attributes,
+			ErrMsg:     c.ErrMsg,
+			Priority:   c.Priority,
+			L10n:       c.L10n,
+		}
+	}
+	return constants
+}
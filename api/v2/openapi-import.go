@@ -0,0 +1,265 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	basic "github.com/ashbeelghouri/jsonschematics/api/v0"
+	jsonschematics "github.com/ashbeelghouri/jsonschematics/data/v0"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var openAPIPathParamRegex = regexp.MustCompile(`\{([^/}]+)\}`)
+
+func LoadOpenAPIFile(path string) (*basic.Schema, error) {
+	var schema Schema
+	schema.Configs()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		Logs.ERROR("Failed to load openapi file", err)
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = utils.UnmarshalSchema(content, utils.DetectFormat(path, content), &doc)
+	if err != nil {
+		Logs.ERROR("Failed to unmarshall openapi file", err)
+		return nil, err
+	}
+	return LoadOpenAPIMap(doc)
+}
+
+func LoadOpenAPIMap(doc map[string]interface{}) (*basic.Schema, error) {
+	schema, untranslated, err := FromOpenAPI(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyword := range untranslated {
+		Logs.DEBUG("keyword could not be translated:", keyword.Path, keyword.Keyword)
+	}
+	return schema.transformTov0(), nil
+}
+
+// FromOpenAPI builds the schema from an openapi 3 document, endpoints are keyed by the method and the path,
+// local references are resolved and the keywords that could not be translated are returned
+func FromOpenAPI(doc map[string]interface{}) (*Schema, []jsonschematics.UntranslatedKeyword, error) {
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, nil, errors.New("only openapi 3 documents are supported")
+	}
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, nil, errors.New("openapi document does not have any paths")
+	}
+
+	var schema Schema
+	schema.Endpoints = make(map[string]Endpoint)
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		schema.Version, _ = info["version"].(string)
+	}
+	var untranslated []jsonschematics.UntranslatedKeyword
+
+	for path, value := range paths {
+		item, ok := resolveOpenAPIRefs(value, doc, nil).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pointer := "/paths/" + utils.EscapeJsonPointer(path)
+		schematicsPath := openAPIPathParamRegex.ReplaceAllString(path, ":$1")
+		sharedParameters, _ := item["parameters"].([]interface{})
+
+		for _, method := range openAPIMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			endpoint := Endpoint{
+				Path: schematicsPath,
+				Type: strings.ToUpper(method),
+			}
+			operationPointer := pointer + "/" + method
+			// the shared parameters are reported under the path and the parameters of the operation under the operation
+			var parameters []interface{}
+			var parameterPointers []string
+			for i, p := range sharedParameters {
+				parameters = append(parameters, p)
+				parameterPointers = append(parameterPointers, fmt.Sprintf("%s/parameters/%d", pointer, i))
+			}
+			operationParameters, _ := operation["parameters"].([]interface{})
+			for i, p := range operationParameters {
+				parameters = append(parameters, p)
+				parameterPointers = append(parameterPointers, fmt.Sprintf("%s/parameters/%d", operationPointer, i))
+			}
+			for i, p := range parameters {
+				parameter, ok := p.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				parameterPointer := parameterPointers[i]
+				name, _ := parameter["name"].(string)
+				required, _ := parameter["required"].(bool)
+				parameterSchema, _ := parameter["schema"].(map[string]interface{})
+				fields, skipped := openAPISchemaToFields(map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{name: parameterSchema},
+					"required":   requiredList(name, required),
+				}, parameterPointer)
+				for _, keyword := range skipped {
+					keyword.Path = strings.Replace(keyword.Path, parameterPointer+"/properties/"+utils.EscapeJsonPointer(name), parameterPointer+"/schema", 1)
+					untranslated = append(untranslated, keyword)
+				}
+
+				switch parameter["in"] {
+				case "path":
+					endpoint.Params = mergeFields(endpoint.Params, fields)
+				case "query":
+					endpoint.Query = mergeFields(endpoint.Query, fields)
+				case "header":
+					for i := range fields {
+						fields[i].Key = http.CanonicalHeaderKey(fields[i].Key)
+					}
+					endpoint.Headers = mergeFields(endpoint.Headers, fields)
+				default:
+					untranslated = append(untranslated, jsonschematics.UntranslatedKeyword{Path: parameterPointer, Keyword: "in"})
+				}
+			}
+
+			if requestBody, ok := operation["requestBody"].(map[string]interface{}); ok {
+				content, _ := requestBody["content"].(map[string]interface{})
+				media, ok := content["application/json"].(map[string]interface{})
+				if ok {
+					if bodySchema, ok := media["schema"].(map[string]interface{}); ok {
+						fields, skipped := openAPISchemaToFields(bodySchema, operationPointer+"/requestBody/content/application~1json/schema")
+						untranslated = append(untranslated, skipped...)
+						endpoint.Body = fields
+					}
+				} else if len(content) > 0 {
+					untranslated = append(untranslated, jsonschematics.UntranslatedKeyword{Path: operationPointer + "/requestBody", Keyword: "content"})
+				}
+			}
+			schema.Endpoints[endpoint.Type+" "+schematicsPath] = endpoint
+		}
+	}
+	return &schema, untranslated, nil
+}
+
+func openAPISchemaToFields(node map[string]interface{}, pointer string) ([]Field, []jsonschematics.UntranslatedKeyword) {
+	baseSchema, untranslated := jsonschematics.JsonSchemaToSchema(node, ".")
+	for i := range untranslated {
+		untranslated[i].Path = pointer + untranslated[i].Path
+	}
+	var targets []string
+	for target := range baseSchema.Fields {
+		targets = append(targets, string(target))
+	}
+	sort.Strings(targets)
+
+	var fields []Field
+	for _, target := range targets {
+		baseField := baseSchema.Fields[jsonschematics.TargetKey(target)]
+		field := Field{
+			DependsOn:             baseField.DependsOn,
+			Type:                  baseField.Type,
+			Key:                   target,
+			Validators:            fromDataConstants(baseField.Validators),
+			Operators:             fromDataConstants(baseField.Operators),
+			L10n:                  baseField.L10n,
+			AdditionalInformation: baseField.AdditionalInformation,
+		}
+		if baseField.IsRequired {
+			field.Validators = append([]Component{{Name: "IsRequired"}}, field.Validators...)
+		}
+		fields = append(fields, field)
+	}
+	return fields, untranslated
+}
+
+func fromDataConstants(constants map[string]jsonschematics.Constant) []Component {
+	var names []string
+	for name := range constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var components []Component
+	for _, name := range names {
+		components = append(components, Component{
+			Name:       name,
+			Attributes: constants[name].Attributes,
+			ErrMsg:     constants[name].Error,
+			L10n:       constants[name].L10n,
+		})
+	}
+	return components
+}
+
+func mergeFields(fields []Field, newFields []Field) []Field {
+	for _, newField := range newFields {
+		replaced := false
+		for i, field := range fields {
+			if field.Key == newField.Key {
+				fields[i] = newField
+				replaced = true
+			}
+		}
+		if !replaced {
+			fields = append(fields, newField)
+		}
+	}
+	return fields
+}
+
+func requiredList(name string, required bool) []interface{} {
+	if required {
+		return []interface{}{name}
+	}
+	return []interface{}{}
+}
+
+// resolveOpenAPIRefs inlines the local references of the document, cyclic references are left as they are
+func resolveOpenAPIRefs(node interface{}, doc map[string]interface{}, seen []string) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := n["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+			for _, r := range seen {
+				if r == ref {
+					return n
+				}
+			}
+			target := lookupPointer(doc, ref[2:])
+			if target == nil {
+				return n
+			}
+			return resolveOpenAPIRefs(target, doc, append(seen, ref))
+		}
+		resolved := make(map[string]interface{})
+		for key, value := range n {
+			resolved[key] = resolveOpenAPIRefs(value, doc, seen)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(n))
+		for i, value := range n {
+			resolved[i] = resolveOpenAPIRefs(value, doc, seen)
+		}
+		return resolved
+	}
+	return node
+}
+
+func lookupPointer(doc map[string]interface{}, pointer string) interface{} {
+	var current interface{} = doc
+	for _, segment := range strings.Split(pointer, "/") {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[segment]
+	}
+	return current
+}
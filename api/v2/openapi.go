@@ -0,0 +1,160 @@
+package v2
+
+import (
+	"encoding/json"
+	jsonschematics "github.com/ashbeelghouri/jsonschematics/data/v0"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const OpenAPIVersion = "3.1.0"
+
+var pathParamRegex = regexp.MustCompile(`:([^/]+)`)
+
+func (s *Schema) ToOpenAPI(title string) map[string]interface{} {
+	if title == "" {
+		title = "api"
+	}
+	version := s.Version
+	if version == "" {
+		version = "0"
+	}
+	doc := map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+	}
+
+	var globalParameters []interface{}
+	if len(s.Global.Headers) > 0 {
+		parameters := make(map[string]interface{})
+		for _, field := range s.Global.Headers {
+			name := "header-" + field.Key
+			parameters[name] = fieldToParameter(field, "header")
+			globalParameters = append(globalParameters, map[string]interface{}{
+				"$ref": "#/components/parameters/" + name,
+			})
+		}
+		doc["components"] = map[string]interface{}{"parameters": parameters}
+	}
+
+	var keys []string
+	for key := range s.Endpoints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	paths := make(map[string]interface{})
+	for _, key := range keys {
+		endpoint := s.Endpoints[key]
+		path := endpoint.Path
+		if path == "" {
+			path = key
+		}
+		pathParams := pathParamRegex.FindAllStringSubmatch(path, -1)
+		path = pathParamRegex.ReplaceAllString(path, "{$1}")
+		method := strings.ToLower(endpoint.Type)
+		if method == "" {
+			method = "get"
+		}
+
+		operation := make(map[string]interface{})
+		parameters := append([]interface{}{}, globalParameters...)
+		params := make(map[string]Field)
+		for _, field := range endpoint.Params {
+			params[field.Key] = field
+		}
+		for _, match := range pathParams {
+			parameter := map[string]interface{}{
+				"name":   match[1],
+				"in":     "path",
+				"schema": map[string]interface{}{"type": "string"},
+			}
+			if field, ok := params[match[1]]; ok {
+				parameter = fieldToParameter(field, "path")
+			}
+			// openapi requires every path parameter to be required
+			parameter["required"] = true
+			parameters = append(parameters, parameter)
+		}
+		for _, field := range endpoint.Headers {
+			parameters = append(parameters, fieldToParameter(field, "header"))
+		}
+		for _, field := range endpoint.Query {
+			parameters = append(parameters, fieldToParameter(field, "query"))
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if len(endpoint.Body) > 0 {
+			fields := make(map[jsonschematics.TargetKey]jsonschematics.Field)
+			bodyRequired := false
+			for _, field := range endpoint.Body {
+				baseField := toDataField(field)
+				fields[jsonschematics.TargetKey(field.Key)] = baseField
+				if baseField.Required() {
+					bodyRequired = true
+				}
+			}
+			operation["requestBody"] = map[string]interface{}{
+				"required": bodyRequired,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": jsonschematics.FieldsToJsonSchema(fields, "."),
+					},
+				},
+			}
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[path] = item
+		}
+		item[method] = operation
+	}
+	doc["paths"] = paths
+	return doc
+}
+
+func (s *Schema) ExportOpenAPI(title string) ([]byte, error) {
+	return json.MarshalIndent(s.ToOpenAPI(title), "", "  ")
+}
+
+func fieldToParameter(field Field, in string) map[string]interface{} {
+	baseField := toDataField(field)
+	parameter := map[string]interface{}{
+		"name":     field.Key,
+		"in":       in,
+		"required": baseField.Required(),
+		"schema":   jsonschematics.FieldToJsonSchema(baseField),
+	}
+	return parameter
+}
+
+func toDataField(field Field) jsonschematics.Field {
+	return jsonschematics.Field{
+		DependsOn:             field.DependsOn,
+		Type:                  field.Type,
+		Validators:            toDataConstants(field.Validators),
+		Operators:             toDataConstants(field.Operators),
+		L10n:                  field.L10n,
+		AdditionalInformation: field.AdditionalInformation,
+	}
+}
+
+func toDataConstants(components []Component) map[string]jsonschematics.Constant {
+	constants := make(map[string]jsonschematics.Constant)
+	for _, c := range components {
+		constants[c.Name] = jsonschematics.Constant{
+			Attributes: c.Attributes,
+			Error:      c.ErrMsg,
+			L10n:       c.L10n,
+		}
+	}
+	return constants
+}
@@ -2,9 +2,12 @@ package v2
 
 import (
 	"encoding/json"
+	"fmt"
 	basic "github.com/ashbeelghouri/jsonschematics/api/v0"
 	"github.com/ashbeelghouri/jsonschematics/errorHandler"
 	"github.com/ashbeelghouri/jsonschematics/utils"
+	"io"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
@@ -16,36 +19,75 @@ type Schema struct {
 	Version   string              `json:"version"`
 	Global    Global              `json:"global"`
 	Endpoints map[string]Endpoint `json:"endpoints"`
-	Locale    string
-	Logger    utils.Logger
+	Locale    string              `json:"locale,omitempty"`
+	Logger    utils.Logger        `json:"-"`
+	// Coerce converts the path parameters, the headers and the query parameters to the type of their field
+	Coerce bool `json:"coerce,omitempty"`
 }
 
 type Global struct {
-	Headers []Field `json:"headers"`
+	Headers []Field `json:"headers,omitempty"`
 }
 
 type Endpoint struct {
-	Path    string  `json:"path"`
+	Path    string  `json:"path,omitempty"`
 	Type    string  `json:"type"`
-	Body    []Field `json:"body"`
-	Headers []Field `json:"headers"`
-	Query   []Field `json:"query"`
+	Body    []Field `json:"body,omitempty"`
+	Headers []Field `json:"headers,omitempty"`
+	Query   []Field `json:"query,omitempty"`
+	Params  []Field `json:"params,omitempty"`
 }
 
 type Field struct {
-	DependsOn             []string
+	DependsOn             []string               `json:"depends_on,omitempty"`
 	Key                   string                 `json:"target_key"`
-	Validators            []Component            `json:"validators"`
-	Operators             []Component            `json:"operators"`
-	L10n                  map[string]interface{} `json:"l10n"`
-	AdditionalInformation map[string]interface{} `json:"additional_information"`
+	Type                  string                 `json:"type,omitempty"`
+	Validators            []Component            `json:"validators,omitempty"`
+	Operators             []Component            `json:"operators,omitempty"`
+	L10n                  map[string]interface{} `json:"l10n,omitempty"`
+	AdditionalInformation map[string]interface{} `json:"additional_information,omitempty"`
 }
 
 type Component struct {
-	Name       string
-	Attributes map[string]interface{}
-	ErrMsg     string
-	L10n       map[string]interface{}
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	ErrMsg     string                 `json:"error,omitempty"`
+	L10n       map[string]interface{} `json:"l10n,omitempty"`
+	Priority   int                    `json:"priority,omitempty"`
+}
+
+// UnmarshalJSON also reads the DependsOn key of the files written before depends_on
+func (f *Field) UnmarshalJSON(content []byte) error {
+	type plain Field
+	var field struct {
+		plain
+		LegacyDependsOn []string `json:"DependsOn"`
+	}
+	if err := json.Unmarshal(content, &field); err != nil {
+		return err
+	}
+	*f = Field(field.plain)
+	if f.DependsOn == nil {
+		f.DependsOn = field.LegacyDependsOn
+	}
+	return nil
+}
+
+// UnmarshalJSON also reads the ErrMsg key of the files written before error
+func (c *Component) UnmarshalJSON(content []byte) error {
+	type plain Component
+	var component struct {
+		plain
+		LegacyErrMsg string `json:"ErrMsg"`
+	}
+	if err := json.Unmarshal(content, &component); err != nil {
+		return err
+	}
+	*c = Component(component.plain)
+	if c.ErrMsg == "" {
+		c.ErrMsg = component.LegacyErrMsg
+	}
+	return nil
 }
 
 func (s *Schema) Configs() {
@@ -59,22 +101,79 @@ func (s *Schema) Configs() {
 }
 
 func LoadJsonSchemaFile(path string) (*basic.Schema, error) {
-	var schema Schema
-	schema.Configs()
+	return loadSchemaFile(path, utils.FormatJson)
+}
+
+func LoadYamlSchemaFile(path string) (*basic.Schema, error) {
+	return loadSchemaFile(path, utils.FormatYaml)
+}
+
+func LoadSchemaFile(path string) (*basic.Schema, error) {
+	return loadSchemaFile(path, "")
+}
+
+func loadSchemaFile(path string, format string) (*basic.Schema, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		Logs.ERROR("Failed to load schema file", err)
 		return nil, err
 	}
-	err = json.Unmarshal(content, &schema)
+	return loadSchema(path, content, format)
+}
+
+// LoadSchemaFS loads the schema file from the file system, for example an embed.FS
+func LoadSchemaFS(fsys fs.FS, name string) (*basic.Schema, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		Logs.ERROR("Failed to load schema file", err)
+		return nil, err
+	}
+	return loadSchema(name, content, "")
+}
+
+// LoadSchemaReader reads a json or yaml schema, the format is detected from the content when it is empty
+func LoadSchemaReader(r io.Reader, format string) (*basic.Schema, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		Logs.ERROR("Failed to read schema", err)
+		return nil, err
+	}
+	return loadSchema("", content, format)
+}
+
+// LoadSchemasFS loads every file of the file system matching the glob pattern, keyed by the path of the file
+func LoadSchemasFS(fsys fs.FS, pattern string) (map[string]*basic.Schema, error) {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*basic.Schema)
+	for _, name := range names {
+		schema, err := LoadSchemaFS(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+func loadSchema(path string, content []byte, format string) (*basic.Schema, error) {
+	var schema Schema
+	schema.Configs()
+	if format == "" {
+		format = utils.DetectFormat(path, content)
+	}
+	err := utils.UnmarshalSchema(content, format, &schema)
 	if err != nil {
+		Logs.ERROR("Failed to unmarshall schema file", err)
 		return nil, err
 	}
 	return schema.transformTov0(), nil
 }
 
 func LoadMap(schemaMap interface{}) (*basic.Schema, error) {
-	var s *Schema
+	var s Schema
 	s.Configs()
 	jsonBytes, err := json.Marshal(schemaMap)
 	if err != nil {
@@ -88,12 +187,21 @@ func LoadMap(schemaMap interface{}) (*basic.Schema, error) {
 	return s.transformTov0(), nil
 }
 
+func componentNames(components []Component) []string {
+	var names []string
+	for _, c := range components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
 func transformComponents(components []Component) map[basic.TargetKey]basic.Constant {
 	validators := map[basic.TargetKey]basic.Constant{}
 	for _, validator := range components {
 		validators[basic.TargetKey(validator.Name)] = basic.Constant{
 			Attributes: validator.Attributes,
 			ErrMsg:     validator.ErrMsg,
+			Priority:   validator.Priority,
 			L10n:       validator.L10n,
 		}
 	}
@@ -104,16 +212,20 @@ func (s *Schema) transformTov0() *basic.Schema {
 	var baseSchema basic.Schema
 	baseSchema.Version = s.Version
 	baseSchema.Locale = s.Locale
+	baseSchema.Coerce = s.Coerce
 	baseSchema.Logger = s.Logger
 	global := basic.Global{Headers: map[basic.TargetKey]basic.Field{}}
 	endpoints := map[basic.EndpointKey]basic.Endpoint{}
 
 	for _, field := range s.Global.Headers {
 		global.Headers[basic.TargetKey(field.Key)] = basic.Field{
-			DependsOn:  field.DependsOn,
-			Validators: transformComponents(field.Validators),
-			Operators:  transformComponents(field.Operators),
-			L10n:       field.L10n,
+			DependsOn:             field.DependsOn,
+			Type:                  field.Type,
+			Validators:            transformComponents(field.Validators),
+			ValidatorOrder:        componentNames(field.Validators),
+			Operators:             transformComponents(field.Operators),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
 		}
 	}
 
@@ -121,36 +233,60 @@ func (s *Schema) transformTov0() *basic.Schema {
 		headers := map[basic.TargetKey]basic.Field{}
 		for _, field := range endpoint.Headers {
 			headers[basic.TargetKey(field.Key)] = basic.Field{
-				DependsOn:  field.DependsOn,
-				Validators: transformComponents(field.Validators),
-				Operators:  transformComponents(field.Operators),
-				L10n:       field.L10n,
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        componentNames(field.Validators),
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
 			}
 		}
 		body := map[basic.TargetKey]basic.Field{}
 		for _, field := range endpoint.Body {
 			body[basic.TargetKey(field.Key)] = basic.Field{
-				DependsOn:  field.DependsOn,
-				Validators: transformComponents(field.Validators),
-				Operators:  transformComponents(field.Operators),
-				L10n:       field.L10n,
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        componentNames(field.Validators),
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
 			}
 		}
 
 		query := map[basic.TargetKey]basic.Field{}
-		for _, field := range endpoint.Body {
+		for _, field := range endpoint.Query {
 			query[basic.TargetKey(field.Key)] = basic.Field{
-				DependsOn:  field.DependsOn,
-				Validators: transformComponents(field.Validators),
-				Operators:  transformComponents(field.Operators),
-				L10n:       field.L10n,
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        componentNames(field.Validators),
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
 			}
 		}
+		params := map[basic.TargetKey]basic.Field{}
+		for _, field := range endpoint.Params {
+			params[basic.TargetKey(field.Key)] = basic.Field{
+				DependsOn:             field.DependsOn,
+				Type:                  field.Type,
+				Validators:            transformComponents(field.Validators),
+				ValidatorOrder:        componentNames(field.Validators),
+				Operators:             transformComponents(field.Operators),
+				L10n:                  field.L10n,
+				AdditionalInformation: field.AdditionalInformation,
+			}
+		}
+
 		endpoints[basic.EndpointKey(path)] = basic.Endpoint{
+			Path:    endpoint.Path,
 			Type:    endpoint.Type,
 			Body:    body,
 			Headers: headers,
 			Query:   query,
+			Params:  params,
 		}
 	}
 	baseSchema.Global = global
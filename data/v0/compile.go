@@ -0,0 +1,196 @@
+package v0
+
+import (
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type SchemaError struct {
+	Target  string `json:"target"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type SchemaErrors struct {
+	Errors []SchemaError
+}
+
+func (se *SchemaErrors) AddError(target string, path string, message string) {
+	se.Errors = append(se.Errors, SchemaError{
+		Target:  target,
+		Path:    path,
+		Message: message,
+	})
+}
+
+func (se *SchemaErrors) HasErrors() bool {
+	return se != nil && len(se.Errors) > 0
+}
+
+func (se *SchemaErrors) GetStrings() []string {
+	var errs []string
+	if !se.HasErrors() {
+		return errs
+	}
+	for _, e := range se.Errors {
+		if e.Path != "" {
+			errs = append(errs, fmt.Sprintf("%s [%s]: %s", e.Target, e.Path, e.Message))
+		} else {
+			errs = append(errs, fmt.Sprintf("%s: %s", e.Target, e.Message))
+		}
+	}
+	return errs
+}
+
+func (se *SchemaErrors) Error() string {
+	return strings.Join(se.GetStrings(), "\n")
+}
+
+// Compile lints the loaded schema against the registered validators and operators,
+// it returns nil when the schema has no problems.
+func (s *Schematics) Compile() *SchemaErrors {
+	var errs SchemaErrors
+	if s.Separator == "" {
+		s.Separator = "."
+	}
+	if s.Validators.ValidationFns == nil {
+		s.Validators.BasicValidators()
+	}
+	if s.Operators.OpFunctions == nil {
+		s.Operators.LoadBasicOperations()
+	}
+
+	for _, target := range sortedTargets(s.Schema.Fields) {
+		field := s.Schema.Fields[target]
+		t := string(target)
+		if msg := s.checkTargetKey(t); msg != "" {
+			errs.AddError(t, "target_key", msg)
+		}
+
+		for i, d := range field.DependsOn {
+			path := fmt.Sprintf("depends_on.%d", i)
+			if msg := s.checkTargetKey(d); msg != "" {
+				errs.AddError(t, path, msg)
+				continue
+			}
+			if _, exists := s.Schema.Fields[TargetKey(d)]; !exists {
+				errs.AddError(t, path, fmt.Sprintf("depends on unknown target %s", d))
+			}
+		}
+
+		s.checkCondition(&errs, t, "when", field.When)
+		if field.Default != nil && len(field.Validators) > 0 {
+			if err := field.Validate(s.defaultValue(field.Default), s.Validators.ValidationFns, nil); err != nil {
+				errs.AddError(t, "default", fmt.Sprintf("default is not valid: %s", err.Message["en"]))
+			}
+		}
+
+		for _, name := range sortedConstants(field.Validators) {
+			path := "validators." + name
+			if utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators) {
+				continue
+			}
+			s.checkCondition(&errs, t, path+".when", field.Validators[name].When)
+			if _, exists := s.Validators.ValidationFns[name]; !exists {
+				errs.AddError(t, path, "validator not registered")
+				continue
+			}
+			attributes := field.Validators[name].Attributes
+			if descriptor, exists := s.Validators.GetDescriptor(name); exists {
+				checkDescriptor(&errs, t, path, descriptor, attributes, field.Type)
+				if descriptor.ComparesField && !comparesField(attributes) {
+					errs.AddError(t, path+".attributes", "field or sibling attribute is required")
+				}
+			}
+			if key, ok := attributes[validators.FieldAttribute].(string); ok && key != "" {
+				if msg := s.checkTargetKey(key); msg != "" {
+					errs.AddError(t, path+".attributes.field", msg)
+				}
+			}
+			if name == "MatchRegex" {
+				if pattern, ok := attributes["regex"].(string); ok {
+					if _, err := regexp.Compile(pattern); err != nil {
+						errs.AddError(t, path+".attributes.regex", fmt.Sprintf("invalid regex: %v", err))
+					}
+				}
+			}
+		}
+
+		for _, name := range sortedConstants(field.Operators) {
+			path := "operators." + name
+			if _, exists := s.Operators.OpFunctions[name]; !exists {
+				errs.AddError(t, path, "operator not registered")
+				continue
+			}
+			if descriptor, exists := s.Operators.GetDescriptor(name); exists {
+				checkDescriptor(&errs, t, path, descriptor, field.Operators[name].Attributes, field.Type)
+			}
+		}
+	}
+
+	if errs.HasErrors() {
+		return &errs
+	}
+	return nil
+}
+
+func checkDescriptor(errs *SchemaErrors, target string, path string, descriptor utils.Descriptor, attributes map[string]interface{}, fieldType string) {
+	problems := descriptor.CheckAttributes(attributes)
+	var names []string
+	for name := range problems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs.AddError(target, path+".attributes."+name, problems[name])
+	}
+	if !descriptor.AppliesToType(fieldType) {
+		errs.AddError(target, path, fmt.Sprintf("%s can not be applied on the %s type", descriptor.Name, fieldType))
+	}
+}
+
+func (s *Schematics) checkTargetKey(key string) string {
+	if strings.TrimSpace(key) == "" {
+		return "target key can not be empty"
+	}
+	segments := strings.Split(key, s.Separator)
+	for i, segment := range segments {
+		if segment == "" {
+			return "target key has an empty segment"
+		}
+		if segment == "*" {
+			if i == 0 {
+				return "wildcard can not be the first segment of the target key"
+			}
+			continue
+		}
+		if strings.Contains(segment, "*") {
+			return fmt.Sprintf("wildcard should be a complete segment, found %s", segment)
+		}
+	}
+	return ""
+}
+
+func sortedTargets(fields map[TargetKey]Field) []TargetKey {
+	var targets []TargetKey
+	for target := range fields {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i] < targets[j]
+	})
+	return targets
+}
+
+func sortedConstants(constants map[string]Constant) []string {
+	var names []string
+	for name := range constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,231 @@
+package v0
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"reflect"
+	"strings"
+)
+
+// Condition is checked against the other values of the document, a field or a validator with a condition
+// is skipped when the condition does not hold. The comparisons of a condition must all hold,
+// all, any and not combine conditions and a condition without a field only combines the others
+type Condition struct {
+	Field              string        `json:"field,omitempty"`
+	Equals             interface{}   `json:"equals,omitempty"`
+	NotEquals          interface{}   `json:"not_equals,omitempty"`
+	In                 []interface{} `json:"in,omitempty"`
+	NotIn              []interface{} `json:"not_in,omitempty"`
+	Exists             *bool         `json:"exists,omitempty"`
+	GreaterThan        *float64      `json:"gt,omitempty"`
+	GreaterThanOrEqual *float64      `json:"gte,omitempty"`
+	LessThan           *float64      `json:"lt,omitempty"`
+	LessThanOrEqual    *float64      `json:"lte,omitempty"`
+	All                []Condition   `json:"all,omitempty"`
+	Any                []Condition   `json:"any,omitempty"`
+	Not                *Condition    `json:"not,omitempty"`
+}
+
+// conditionScope is the flat document and the key being validated,
+// wildcards of the condition field are replaced by the array indexes of the key while the paths are the same
+type conditionScope struct {
+	data      map[string]interface{}
+	target    string
+	key       string
+	separator string
+}
+
+// holds is true for a nil condition
+func (c *Condition) holds(scope conditionScope) bool {
+	if c == nil {
+		return true
+	}
+	for i := range c.All {
+		if !c.All[i].holds(scope) {
+			return false
+		}
+	}
+	if len(c.Any) > 0 {
+		matched := false
+		for i := range c.Any {
+			if c.Any[i].holds(scope) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if c.Not != nil && c.Not.holds(scope) {
+		return false
+	}
+	if c.Field == "" {
+		return true
+	}
+
+	values, exists := scope.lookup(c.Field)
+	if c.Exists != nil && exists != *c.Exists {
+		return false
+	}
+	if !c.compares() {
+		return true
+	}
+	// a missing or null field only satisfies not_equals and not_in, like a not condition around equals and in
+	if len(values) == 0 {
+		return c.matches(nil)
+	}
+	// the comparisons hold when one of the values matching the field satisfies all of them
+	for _, value := range values {
+		if c.matches(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Condition) compares() bool {
+	return c.Equals != nil || c.NotEquals != nil || c.In != nil || c.NotIn != nil ||
+		c.GreaterThan != nil || c.GreaterThanOrEqual != nil || c.LessThan != nil || c.LessThanOrEqual != nil
+}
+
+func (c *Condition) matches(value interface{}) bool {
+	if c.Equals != nil && !conditionEqual(value, c.Equals) {
+		return false
+	}
+	if c.NotEquals != nil && conditionEqual(value, c.NotEquals) {
+		return false
+	}
+	if c.In != nil && !conditionIn(value, c.In) {
+		return false
+	}
+	if c.NotIn != nil && conditionIn(value, c.NotIn) {
+		return false
+	}
+	bounds := []struct {
+		bound   *float64
+		compare func(float64, float64) bool
+	}{
+		{c.GreaterThan, func(v float64, b float64) bool { return v > b }},
+		{c.GreaterThanOrEqual, func(v float64, b float64) bool { return v >= b }},
+		{c.LessThan, func(v float64, b float64) bool { return v < b }},
+		{c.LessThanOrEqual, func(v float64, b float64) bool { return v <= b }},
+	}
+	for _, b := range bounds {
+		if b.bound == nil {
+			continue
+		}
+		number, ok := conditionNumber(value)
+		if !ok || !b.compare(number, *b.bound) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the non null values of the field and whether the field exists, an object exists when it has a nested key
+func (scope conditionScope) lookup(field string) ([]interface{}, bool) {
+	field = scope.resolve(field)
+	var values []interface{}
+	for _, value := range utils.FindMatchingKeys(scope.data, field) {
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+	return values, len(values) > 0 || utils.HasNestedKeys(scope.data, field, scope.separator)
+}
+
+func (scope conditionScope) resolve(field string) string {
+	if scope.key == "" {
+		return field
+	}
+	fieldSegments := strings.Split(field, scope.separator)
+	targetSegments := strings.Split(scope.target, scope.separator)
+	keySegments := strings.Split(scope.key, scope.separator)
+	for i := 0; i < len(fieldSegments) && i < len(targetSegments) && i < len(keySegments); i++ {
+		if fieldSegments[i] != targetSegments[i] {
+			break
+		}
+		if fieldSegments[i] == "*" {
+			fieldSegments[i] = keySegments[i]
+		}
+	}
+	return strings.Join(fieldSegments, scope.separator)
+}
+
+func comparesField(attributes map[string]interface{}) bool {
+	field, _ := attributes[validators.FieldAttribute].(string)
+	sibling, _ := attributes[validators.SiblingAttribute].(string)
+	return field != "" || sibling != ""
+}
+
+// reference is the key of the field or the sibling attribute of a validator comparing two values of the document
+func (scope conditionScope) reference(attributes map[string]interface{}) string {
+	if field, ok := attributes[validators.FieldAttribute].(string); ok && field != "" {
+		return scope.resolve(field)
+	}
+	sibling, _ := attributes[validators.SiblingAttribute].(string)
+	if index := strings.LastIndex(scope.key, scope.separator); index >= 0 {
+		return scope.key[:index+len(scope.separator)] + sibling
+	}
+	return sibling
+}
+
+func conditionEqual(value interface{}, expected interface{}) bool {
+	v, vOk := conditionNumber(value)
+	e, eOk := conditionNumber(expected)
+	if vOk && eOk {
+		return v == e
+	}
+	return reflect.DeepEqual(value, expected)
+}
+
+func conditionIn(value interface{}, options []interface{}) bool {
+	for _, option := range options {
+		if conditionEqual(value, option) {
+			return true
+		}
+	}
+	return false
+}
+
+func conditionNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func (s *Schematics) checkCondition(errs *SchemaErrors, target string, path string, c *Condition) {
+	if c == nil {
+		return
+	}
+	if c.Field == "" {
+		if c.compares() || c.Exists != nil {
+			errs.AddError(target, path, "condition should have a field to compare")
+		} else if len(c.All) == 0 && len(c.Any) == 0 && c.Not == nil {
+			errs.AddError(target, path, "condition is empty")
+		}
+	} else if msg := s.checkTargetKey(c.Field); msg != "" {
+		errs.AddError(target, path+".field", msg)
+	}
+	for i := range c.All {
+		s.checkCondition(errs, target, fmt.Sprintf("%s.all.%d", path, i), &c.All[i])
+	}
+	for i := range c.Any {
+		s.checkCondition(errs, target, fmt.Sprintf("%s.any.%d", path, i), &c.Any[i])
+	}
+	s.checkCondition(errs, target, path+".not", c.Not)
+}
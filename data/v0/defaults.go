@@ -0,0 +1,162 @@
+package v0
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFn makes a dynamic default value, a string default with the name of the function is replaced by its result
+type DefaultFn func() interface{}
+
+// BasicDefaultFns are the dynamic defaults which are always available, Schematics.DefaultFns can add more or replace them
+var BasicDefaultFns = map[string]DefaultFn{
+	"$now":       func() interface{} { return time.Now().UTC().Format(time.RFC3339) },
+	"$today":     func() interface{} { return time.Now().UTC().Format(time.DateOnly) },
+	"$timestamp": func() interface{} { return float64(time.Now().Unix()) },
+	"$uuid":      func() interface{} { return newUUID() },
+}
+
+// applyDefaults sets the default of every field on the flat data when its key is absent,
+// wildcard targets get the default in every item of the array which exists
+func (s *Schematics) applyDefaults(data map[string]interface{}) {
+	for _, target := range sortedTargets(s.Schema.Fields) {
+		field := s.Schema.Fields[target]
+		if field.Default == nil {
+			continue
+		}
+		for _, key := range s.defaultKeys(data, string(target)) {
+			if _, exists := data[key]; exists || utils.HasNestedKeys(data, key, s.Separator) {
+				continue
+			}
+			parents, ok := s.emptyParents(data, key)
+			if !ok {
+				continue
+			}
+			scope := conditionScope{data: data, target: string(target), key: key, separator: s.Separator}
+			if !field.When.holds(scope) {
+				continue
+			}
+			for _, parent := range parents {
+				delete(data, parent)
+			}
+			data[key] = s.defaultValue(field.Default)
+		}
+	}
+}
+
+// defaultKeys replaces the wildcards of the target with the indexes of the arrays in the data
+func (s *Schematics) defaultKeys(data map[string]interface{}, target string) []string {
+	keys := []string{""}
+	for _, segment := range strings.Split(target, s.Separator) {
+		var next []string
+		for _, key := range keys {
+			if segment != "*" {
+				next = append(next, s.joinKey(key, segment))
+				continue
+			}
+			for _, index := range s.arrayIndexes(data, key) {
+				next = append(next, s.joinKey(key, index))
+			}
+		}
+		keys = next
+	}
+	return keys
+}
+
+func (s *Schematics) joinKey(prefix string, segment string) string {
+	if prefix == "" {
+		return segment
+	}
+	return prefix + s.Separator + segment
+}
+
+func (s *Schematics) arrayIndexes(data map[string]interface{}, prefix string) []string {
+	start := "^"
+	if prefix != "" {
+		start += regexp.QuoteMeta(prefix + s.Separator)
+	}
+	re := regexp.MustCompile(start + `(\d+)(` + regexp.QuoteMeta(s.Separator) + `|$)`)
+	found := make(map[int]bool)
+	for key := range data {
+		if match := re.FindStringSubmatch(key); match != nil {
+			index, _ := strconv.Atoi(match[1])
+			found[index] = true
+		}
+	}
+	var indexes []int
+	for index := range found {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	var names []string
+	for _, index := range indexes {
+		names = append(names, strconv.Itoa(index))
+	}
+	return names
+}
+
+// emptyParents returns the empty objects above the key which are replaced by the default,
+// the default can not be set when a parent has any other value
+func (s *Schematics) emptyParents(data map[string]interface{}, key string) ([]string, bool) {
+	var parents []string
+	segments := strings.Split(key, s.Separator)
+	for i := 1; i < len(segments); i++ {
+		parent := strings.Join(segments[:i], s.Separator)
+		value, exists := data[parent]
+		if !exists {
+			continue
+		}
+		if object, ok := value.(map[string]interface{}); !ok || len(object) > 0 {
+			return nil, false
+		}
+		parents = append(parents, parent)
+	}
+	return parents, true
+}
+
+func (s *Schematics) defaultValue(value interface{}) interface{} {
+	if name, ok := value.(string); ok && strings.HasPrefix(name, "$") {
+		if fn, exists := s.DefaultFns[name]; exists {
+			return fn()
+		}
+		if fn, exists := BasicDefaultFns[name]; exists {
+			return fn()
+		}
+	}
+	return copyValue(value)
+}
+
+// copyValue copies the objects and the arrays so the documents do not share a default
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
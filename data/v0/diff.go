@@ -0,0 +1,335 @@
+package v0
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	ChangeFieldAdded         = "field-added"
+	ChangeFieldRemoved       = "field-removed"
+	ChangeRequiredAdded      = "required-added"
+	ChangeRequiredRemoved    = "required-removed"
+	ChangeTypeChanged        = "type-changed"
+	ChangeValidatorAdded     = "validator-added"
+	ChangeValidatorRemoved   = "validator-removed"
+	ChangeAttributeTightened = "attribute-tightened"
+	ChangeAttributeLoosened  = "attribute-loosened"
+	ChangeAttributeChanged   = "attribute-changed"
+	ChangeDependsOnAdded     = "depends-on-added"
+	ChangeDependsOnRemoved   = "depends-on-removed"
+	ChangeNullableChanged    = "nullable-changed"
+	ChangeAllowEmptyChanged  = "allow-empty-changed"
+	ChangeClosedAdded        = "closed-added"
+	ChangeClosedRemoved      = "closed-removed"
+	ChangeDefaultChanged     = "default-changed"
+	ChangeWhenChanged        = "when-changed"
+)
+
+// Change is breaking when data that was valid against the old schema can fail against the new schema
+type Change struct {
+	Target    TargetKey   `json:"target"`
+	Kind      string      `json:"kind"`
+	Validator string      `json:"validator,omitempty"`
+	Attribute string      `json:"attribute,omitempty"`
+	Old       interface{} `json:"old,omitempty"`
+	New       interface{} `json:"new,omitempty"`
+	Breaking  bool        `json:"breaking"`
+}
+
+type SchemaDiff struct {
+	OldVersion string   `json:"old_version"`
+	NewVersion string   `json:"new_version"`
+	Changes    []Change `json:"changes"`
+}
+
+// DiffSchemas compares two schemas using the descriptors of the basic validators,
+// schemas of the other versions can be compared after converting them with ToV0
+func DiffSchemas(old *Schema, new *Schema) *SchemaDiff {
+	var registry validators.Validators
+	registry.BasicValidators()
+	return diffSchemas(old, new, &registry)
+}
+
+// Diff compares the schema with a newer one, attributes of custom validators are classified with their descriptors
+func (s *Schematics) Diff(newer *Schematics) *SchemaDiff {
+	return diffSchemas(&s.Schema, &newer.Schema, &s.Validators)
+}
+
+func (d *SchemaDiff) HasBreakingChanges() bool {
+	for _, change := range d.Changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *SchemaDiff) BreakingChanges() []Change {
+	var breaking []Change
+	for _, change := range d.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// CheckVersion enforces the semver rules on the schema versions, breaking changes need a new major version
+// (a new minor version before 1.0.0) and the other changes need a greater version
+func (d *SchemaDiff) CheckVersion() error {
+	if len(d.Changes) == 0 {
+		return nil
+	}
+	oldVersion, err := utils.ParseSemver(d.OldVersion)
+	if err != nil {
+		return fmt.Errorf("old version: %w", err)
+	}
+	newVersion, err := utils.ParseSemver(d.NewVersion)
+	if err != nil {
+		return fmt.Errorf("new version: %w", err)
+	}
+	if d.HasBreakingChanges() {
+		if oldVersion[0] == 0 && newVersion[0] == 0 {
+			if newVersion[1] <= oldVersion[1] {
+				return fmt.Errorf("breaking changes need a new minor version before 1.0.0, %s is not greater than %s", d.NewVersion, d.OldVersion)
+			}
+			return nil
+		}
+		if newVersion[0] <= oldVersion[0] {
+			return fmt.Errorf("breaking changes need a new major version, %s is not greater than %s", d.NewVersion, d.OldVersion)
+		}
+		return nil
+	}
+	if utils.CompareSemver(newVersion, oldVersion) > 0 {
+		return nil
+	}
+	return fmt.Errorf("schema has changed, %s should be greater than %s", d.NewVersion, d.OldVersion)
+}
+
+func diffSchemas(old *Schema, new *Schema, registry *validators.Validators) *SchemaDiff {
+	diff := SchemaDiff{OldVersion: old.Version, NewVersion: new.Version}
+	for _, target := range sortedTargets(old.Fields) {
+		if _, exists := new.Fields[target]; !exists {
+			diff.Changes = append(diff.Changes, Change{Target: target, Kind: ChangeFieldRemoved})
+		}
+	}
+	for _, target := range sortedTargets(new.Fields) {
+		newField := new.Fields[target]
+		oldField, exists := old.Fields[target]
+		if !exists {
+			diff.Changes = append(diff.Changes, Change{Target: target, Kind: ChangeFieldAdded, Breaking: newField.Required()})
+			continue
+		}
+		diff.Changes = append(diff.Changes, diffFields(target, oldField, newField, registry)...)
+	}
+	return &diff
+}
+
+func diffFields(target TargetKey, old Field, new Field, registry *validators.Validators) []Change {
+	var changes []Change
+	if old.Required() != new.Required() {
+		if new.Required() {
+			changes = append(changes, Change{Target: target, Kind: ChangeRequiredAdded, Breaking: true})
+		} else {
+			changes = append(changes, Change{Target: target, Kind: ChangeRequiredRemoved})
+		}
+	}
+	if old.Type != new.Type {
+		changes = append(changes, Change{Target: target, Kind: ChangeTypeChanged, Old: old.Type, New: new.Type, Breaking: true})
+	}
+	if acceptance(new.Nullable) != acceptance(old.Nullable) {
+		changes = append(changes, Change{Target: target, Kind: ChangeNullableChanged, Old: old.Nullable, New: new.Nullable, Breaking: acceptance(new.Nullable) < acceptance(old.Nullable)})
+	}
+	if acceptance(new.AllowEmpty) != acceptance(old.AllowEmpty) {
+		changes = append(changes, Change{Target: target, Kind: ChangeAllowEmptyChanged, Old: old.AllowEmpty, New: new.AllowEmpty, Breaking: acceptance(new.AllowEmpty) < acceptance(old.AllowEmpty)})
+	}
+	if old.Closed != new.Closed {
+		if new.Closed {
+			changes = append(changes, Change{Target: target, Kind: ChangeClosedAdded, Breaking: true})
+		} else {
+			changes = append(changes, Change{Target: target, Kind: ChangeClosedRemoved})
+		}
+	}
+	// documents which relied on a removed default lose the value after the operations
+	if !reflect.DeepEqual(old.Default, new.Default) {
+		changes = append(changes, Change{Target: target, Kind: ChangeDefaultChanged, Old: old.Default, New: new.Default, Breaking: old.Default != nil && new.Default == nil})
+	}
+	if change, changed := diffWhen(target, "", old.When, new.When); changed {
+		changes = append(changes, change)
+	}
+	for _, d := range old.DependsOn {
+		if !utils.StringInStrings(d, new.DependsOn) {
+			changes = append(changes, Change{Target: target, Kind: ChangeDependsOnRemoved, Old: d})
+		}
+	}
+	for _, d := range new.DependsOn {
+		if !utils.StringInStrings(d, old.DependsOn) {
+			changes = append(changes, Change{Target: target, Kind: ChangeDependsOnAdded, New: d, Breaking: true})
+		}
+	}
+
+	for _, name := range sortedConstants(old.Validators) {
+		if _, exists := new.Validators[name]; !exists && !isRequiredValidator(name) {
+			changes = append(changes, Change{Target: target, Kind: ChangeValidatorRemoved, Validator: name})
+		}
+	}
+	for _, name := range sortedConstants(new.Validators) {
+		if isRequiredValidator(name) {
+			continue
+		}
+		oldConstant, exists := old.Validators[name]
+		if !exists {
+			changes = append(changes, Change{Target: target, Kind: ChangeValidatorAdded, Validator: name, Breaking: true})
+			continue
+		}
+		if change, changed := diffWhen(target, name, oldConstant.When, new.Validators[name].When); changed {
+			changes = append(changes, change)
+		}
+		descriptor, _ := registry.GetDescriptor(name)
+		changes = append(changes, diffAttributes(target, name, oldConstant.Attributes, new.Validators[name].Attributes, descriptor)...)
+	}
+	return changes
+}
+
+// acceptance orders the values of nullable and allow_empty by how much they accept,
+// true skips the validators, nil runs them and false rejects the value
+func acceptance(flag *bool) int {
+	if flag == nil {
+		return 1
+	}
+	if *flag {
+		return 2
+	}
+	return 0
+}
+
+// diffWhen only treats a new condition as loosening, the rules were checked every time before it,
+// a removed or a changed condition can check the rules on data they skipped before
+func diffWhen(target TargetKey, validator string, old *Condition, new *Condition) (Change, bool) {
+	if reflect.DeepEqual(old, new) {
+		return Change{}, false
+	}
+	return Change{Target: target, Kind: ChangeWhenChanged, Validator: validator, Old: old, New: new, Breaking: old != nil}, true
+}
+
+func diffAttributes(target TargetKey, validator string, old map[string]interface{}, new map[string]interface{}, descriptor utils.Descriptor) []Change {
+	names := make(map[string]bool)
+	for name := range old {
+		names[name] = true
+	}
+	for name := range new {
+		names[name] = true
+	}
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, name := range sorted {
+		oldValue, newValue := old[name], new[name]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		change := Change{
+			Target:    target,
+			Kind:      ChangeAttributeChanged,
+			Validator: validator,
+			Attribute: name,
+			Old:       oldValue,
+			New:       newValue,
+			Breaking:  true,
+		}
+		if tightened, known := compareLimit(attributeLimit(descriptor, name), oldValue, newValue); known {
+			change.Kind = ChangeAttributeLoosened
+			change.Breaking = tightened
+			if tightened {
+				change.Kind = ChangeAttributeTightened
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// attributeLimit falls back to the name of the attribute for validators without a descriptor
+func attributeLimit(descriptor utils.Descriptor, name string) string {
+	if attribute := descriptor.GetAttribute(name); attribute != nil {
+		return attribute.Limit
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(lower, "max"):
+		return utils.LimitMax
+	case strings.HasPrefix(lower, "min"):
+		return utils.LimitMin
+	case lower == "options":
+		return utils.LimitOptions
+	}
+	return ""
+}
+
+// compareLimit tells if the new value of the attribute accepts less values than the old value
+func compareLimit(limit string, old interface{}, new interface{}) (bool, bool) {
+	switch limit {
+	case utils.LimitMax, utils.LimitMin:
+		oldValue, oldOk := limitValue(old)
+		newValue, newOk := limitValue(new)
+		if !oldOk || !newOk {
+			return false, false
+		}
+		if limit == utils.LimitMax {
+			return newValue < oldValue, true
+		}
+		return newValue > oldValue, true
+	case utils.LimitOptions:
+		oldOptions, oldOk := old.([]interface{})
+		newOptions, newOk := new.([]interface{})
+		if !oldOk || !newOk {
+			return false, false
+		}
+		for _, option := range oldOptions {
+			found := false
+			for _, newOption := range newOptions {
+				if reflect.DeepEqual(option, newOption) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	return false, false
+}
+
+// limitValue reads numbers and dates so they can be compared
+func limitValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		if date := validators.InterfaceToDate(v); date != nil {
+			return float64(date.UnixNano()), true
+		}
+	}
+	return 0, false
+}
+
+func isRequiredValidator(name string) bool {
+	return utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators)
+}
@@ -0,0 +1,220 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"sort"
+	"strings"
+)
+
+// Inference proposes a draft schema from sample documents, the zero value is ready to use
+type Inference struct {
+	Separator string
+	// MaxOptions is the most distinct values a string field can have to get an option list, 5 by default
+	MaxOptions int
+	documents  int
+	instances  map[string]map[string]bool
+	targets    map[string]*inferredTarget
+}
+
+type inferredTarget struct {
+	present map[string]bool
+	values  []interface{}
+}
+
+// AddSample adds a json object, or every object of a json array, as a sample document
+func (i *Inference) AddSample(content []byte) error {
+	data, err := utils.BytesToMap(content)
+	if err != nil {
+		return err
+	}
+	return i.AddData(data)
+}
+
+func (i *Inference) AddData(data interface{}) error {
+	switch d := data.(type) {
+	case map[string]interface{}:
+		i.addDocument(d)
+	case []map[string]interface{}:
+		for _, document := range d {
+			i.addDocument(document)
+		}
+	case []interface{}:
+		for _, item := range d {
+			document, ok := item.(map[string]interface{})
+			if !ok {
+				return errors.New("samples should be objects or arrays of objects")
+			}
+			i.addDocument(document)
+		}
+	default:
+		return fmt.Errorf("samples should be objects or arrays of objects, found %T", data)
+	}
+	return nil
+}
+
+func (i *Inference) addDocument(document map[string]interface{}) {
+	if i.Separator == "" {
+		i.Separator = "."
+	}
+	if i.instances == nil {
+		i.instances = make(map[string]map[string]bool)
+		i.targets = make(map[string]*inferredTarget)
+	}
+	docID := fmt.Sprintf("%d", i.documents)
+	i.documents++
+
+	var dMap utils.DataMap
+	dMap.FlattenTheMap(document, "", i.Separator)
+	for key, value := range dMap.Data {
+		// empty arrays and objects do not tell anything about their items
+		if _, isString := value.(string); !isString && utils.IsEmptyValue(value) {
+			continue
+		}
+		concrete := strings.Split(key, i.Separator)
+		segments := make([]string, len(concrete))
+		instance := docID
+		for index, segment := range concrete {
+			segments[index] = segment
+			if !utils.IsNumeric(segment) {
+				continue
+			}
+			segments[index] = "*"
+			parent := strings.Join(segments[:index+1], i.Separator)
+			instance = docID + i.Separator + strings.Join(concrete[:index+1], i.Separator)
+			if i.instances[parent] == nil {
+				i.instances[parent] = make(map[string]bool)
+			}
+			i.instances[parent][instance] = true
+		}
+		target := strings.Join(segments, i.Separator)
+		stats, exists := i.targets[target]
+		if !exists {
+			stats = &inferredTarget{present: make(map[string]bool)}
+			i.targets[target] = stats
+		}
+		if value != nil {
+			stats.present[instance] = true
+			stats.values = append(stats.values, value)
+		}
+	}
+}
+
+// Schema proposes the fields seen in the samples, a field is required when it has a value in every sample
+// (or in every item of its array) and the suggested validators pass on all of the sample values
+func (i *Inference) Schema() *Schema {
+	schema := Schema{Fields: make(map[TargetKey]Field)}
+	maxOptions := i.MaxOptions
+	if maxOptions == 0 {
+		maxOptions = 5
+	}
+	for target, stats := range i.targets {
+		segments := strings.Split(target, i.Separator)
+		field := Field{
+			Name:       segments[len(segments)-1],
+			Validators: make(map[string]Constant),
+		}
+		for index := len(segments) - 1; index >= 0 && segments[index] == "*"; index-- {
+			field.Name = ""
+			if index > 0 {
+				field.Name = segments[index-1]
+			}
+		}
+
+		if segments[len(segments)-1] != "*" {
+			total := i.documents
+			for index := len(segments) - 1; index >= 0; index-- {
+				if segments[index] == "*" {
+					total = len(i.instances[strings.Join(segments[:index+1], i.Separator)])
+					break
+				}
+			}
+			field.IsRequired = len(stats.present) == total
+		}
+		inferField(&field, stats.values, maxOptions)
+		schema.Fields[TargetKey(target)] = field
+	}
+	return &schema
+}
+
+func inferField(field *Field, values []interface{}, maxOptions int) {
+	if len(values) == 0 {
+		return
+	}
+	var strs []string
+	kinds := make(map[string]bool)
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			kinds[utils.TypeString] = true
+			strs = append(strs, v)
+		case float64, json.Number:
+			kinds[utils.TypeNumber] = true
+		case bool:
+			kinds[utils.TypeBoolean] = true
+		default:
+			kinds[utils.TypeAny] = true
+		}
+	}
+	if len(kinds) != 1 {
+		return
+	}
+	switch {
+	case kinds[utils.TypeNumber]:
+		field.Type = utils.TypeNumber
+		field.Validators["IsNumber"] = Constant{}
+	case kinds[utils.TypeBoolean]:
+		field.Type = utils.TypeBoolean
+	case kinds[utils.TypeString]:
+		field.Type = utils.TypeString
+		field.Validators["IsString"] = Constant{}
+		switch {
+		case allPass(validators.IsEmail, values):
+			field.Validators["IsEmail"] = Constant{}
+		case allPass(validators.IsValidUuid, values):
+			field.Validators["IsValidUuid"] = Constant{}
+		case allPass(validators.IsValidDate, values):
+			field.Type = utils.TypeDate
+			field.Validators["IsValidDate"] = Constant{}
+		default:
+			if options := optionList(strs, maxOptions); options != nil {
+				field.Validators["StringTakenFromOptions"] = Constant{
+					Attributes: map[string]interface{}{"options": options},
+				}
+			}
+		}
+	}
+}
+
+func allPass(fn validators.Validator, values []interface{}) bool {
+	for _, value := range values {
+		if fn(value, nil) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// optionList returns the distinct values when there are only a few of them and they repeat across the samples
+func optionList(values []string, maxOptions int) []interface{} {
+	distinct := make(map[string]bool)
+	for _, value := range values {
+		distinct[value] = true
+	}
+	if len(distinct) > maxOptions || len(distinct) == len(values) {
+		return nil
+	}
+	var sorted []string
+	for value := range distinct {
+		sorted = append(sorted, value)
+	}
+	sort.Strings(sorted)
+	options := make([]interface{}, len(sorted))
+	for index, value := range sorted {
+		options[index] = value
+	}
+	return options
+}
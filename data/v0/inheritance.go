@@ -0,0 +1,365 @@
+package v0
+
+import (
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"reflect"
+	"sort"
+)
+
+// conflict strategies decide what happens when the child schema redefines
+// a validator, operator or property which is already defined by the parent schema
+const (
+	ConflictOverride   = "override"
+	ConflictKeepParent = "keep-parent"
+	ConflictMerge      = "merge"
+	ConflictError      = "error"
+	// MergeReplace can only be set on a field, the field of the parent schema is dropped entirely
+	MergeReplace = "replace"
+)
+
+// FieldOrigin records the file which defined the field last and the file every validator and operator came from
+type FieldOrigin struct {
+	Source     string            `json:"source"`
+	Validators map[string]string `json:"validators,omitempty"`
+	Operators  map[string]string `json:"operators,omitempty"`
+}
+
+type ResolvedField struct {
+	Target TargetKey   `json:"target"`
+	Field  Field       `json:"field"`
+	Origin FieldOrigin `json:"origin"`
+}
+
+// ResolvedView lists the fields after includes and parent schemas are applied, sorted by the target,
+// with the file every validator and operator is coming from
+func (s *Schematics) ResolvedView() []ResolvedField {
+	var view []ResolvedField
+	for _, target := range sortedTargets(s.Schema.Fields) {
+		field := s.Schema.Fields[target]
+		origin, exists := s.Schema.origins[target]
+		if !exists {
+			origin = newFieldOrigin("", field)
+		}
+		view = append(view, ResolvedField{
+			Target: target,
+			Field:  field,
+			Origin: origin.copy(),
+		})
+	}
+	return view
+}
+
+func newFieldOrigin(source string, field Field) FieldOrigin {
+	origin := FieldOrigin{
+		Source:     source,
+		Validators: make(map[string]string),
+		Operators:  make(map[string]string),
+	}
+	for name := range field.Validators {
+		origin.Validators[name] = source
+	}
+	for name := range field.Operators {
+		origin.Operators[name] = source
+	}
+	return origin
+}
+
+func (o FieldOrigin) copy() FieldOrigin {
+	copied := FieldOrigin{
+		Source:     o.Source,
+		Validators: make(map[string]string, len(o.Validators)),
+		Operators:  make(map[string]string, len(o.Operators)),
+	}
+	for name, source := range o.Validators {
+		copied.Validators[name] = source
+	}
+	for name, source := range o.Operators {
+		copied.Operators[name] = source
+	}
+	return copied
+}
+
+// extend applies the child schema on top of the fields of the parent schema,
+// the parent schema is resolved like an include so cycles are detected as well
+func (r *schemaResolver) extend(name string, schema *Schema) error {
+	parentName := r.join(name, schema.Extends)
+	parent, err := r.resolve(parentName)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[TargetKey]Field, len(parent.Fields))
+	origins := make(map[TargetKey]FieldOrigin, len(parent.Fields))
+	for target, field := range parent.Fields {
+		field.Validators = copyConstants(field.Validators)
+		field.Operators = copyConstants(field.Operators)
+		fields[target] = field
+		if origin, exists := parent.origins[target]; exists {
+			origins[target] = origin.copy()
+		} else {
+			origins[target] = newFieldOrigin(parentName, field)
+		}
+	}
+	for _, target := range schema.RemoveFields {
+		if _, exists := fields[target]; !exists {
+			return fmt.Errorf("remove_fields: field %s is not defined in %s", target, schema.Extends)
+		}
+		delete(fields, target)
+		delete(origins, target)
+	}
+
+	for _, target := range sortedTargets(schema.Fields) {
+		child := schema.Fields[target]
+		childOrigin, exists := schema.origins[target]
+		if !exists {
+			childOrigin = newFieldOrigin(name, child)
+		}
+		parentField, exists := fields[target]
+		if !exists || child.Merge == MergeReplace {
+			child.Merge = ""
+			child.RemoveValidators = nil
+			child.RemoveOperators = nil
+			child.RemoveFlags = nil
+			fields[target] = child
+			origins[target] = childOrigin
+			continue
+		}
+		origin := origins[target]
+		merged, err := r.mergeField(parentField, child, &origin, childOrigin)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", target, err)
+		}
+		fields[target] = merged
+		origins[target] = origin
+	}
+
+	if schema.Version == "" {
+		schema.Version = parent.Version
+	}
+	schema.Fields = fields
+	schema.origins = origins
+	schema.Extends = ""
+	schema.RemoveFields = nil
+	return nil
+}
+
+func (r *schemaResolver) mergeField(parent Field, child Field, origin *FieldOrigin, childOrigin FieldOrigin) (Field, error) {
+	strategy := child.Merge
+	if strategy == "" {
+		strategy = r.strategy
+	}
+	if strategy == "" {
+		strategy = ConflictOverride
+	}
+	switch strategy {
+	case ConflictOverride, ConflictKeepParent, ConflictMerge, ConflictError:
+	default:
+		return parent, fmt.Errorf("unknown conflict strategy %s", strategy)
+	}
+
+	merged := parent
+	var err error
+	properties := []struct {
+		name   string
+		parent *string
+		child  string
+	}{
+		{"display_name", &merged.DisplayName, child.DisplayName},
+		{"name", &merged.Name, child.Name},
+		{"type", &merged.Type, child.Type},
+		{"description", &merged.Description, child.Description},
+	}
+	for _, property := range properties {
+		*property.parent, err = mergeProperty(property.name, *property.parent, property.child, strategy)
+		if err != nil {
+			return parent, err
+		}
+	}
+	merged.IsRequired = parent.IsRequired || child.IsRequired
+	merged.Closed = parent.Closed || child.Closed
+	if merged.Nullable, err = mergeFlag("nullable", parent.Nullable, child.Nullable, strategy); err != nil {
+		return parent, err
+	}
+	if merged.AllowEmpty, err = mergeFlag("allow_empty", parent.AllowEmpty, child.AllowEmpty, strategy); err != nil {
+		return parent, err
+	}
+	if child.Default != nil && !reflect.DeepEqual(parent.Default, child.Default) {
+		switch {
+		case parent.Default == nil || strategy == ConflictOverride || strategy == ConflictMerge:
+			merged.Default = child.Default
+		case strategy == ConflictError:
+			return parent, fmt.Errorf("default is already defined")
+		}
+	}
+	if child.When != nil && !reflect.DeepEqual(parent.When, child.When) {
+		switch {
+		case parent.When == nil || strategy == ConflictOverride || strategy == ConflictMerge:
+			merged.When = child.When
+		case strategy == ConflictError:
+			return parent, fmt.Errorf("when is already defined")
+		}
+	}
+	for _, d := range child.DependsOn {
+		if !utils.StringInStrings(d, merged.DependsOn) {
+			merged.DependsOn = append(merged.DependsOn, d)
+		}
+	}
+	if merged.L10n, err = mergeMaps("l10n", parent.L10n, child.L10n, strategy); err != nil {
+		return parent, err
+	}
+	if merged.AdditionalInformation, err = mergeMaps("additional_information", parent.AdditionalInformation, child.AdditionalInformation, strategy); err != nil {
+		return parent, err
+	}
+
+	merged.ValidatorOrder = mergeOrder(parent.ValidatorOrder, child.ValidatorOrder)
+	merged.Validators, err = mergeConstants("validator", parent.Validators, child.Validators, strategy, origin.Validators, childOrigin.Validators)
+	if err != nil {
+		return parent, err
+	}
+	merged.Operators, err = mergeConstants("operator", parent.Operators, child.Operators, strategy, origin.Operators, childOrigin.Operators)
+	if err != nil {
+		return parent, err
+	}
+	for _, validator := range child.RemoveValidators {
+		if _, exists := merged.Validators[validator]; !exists {
+			return parent, fmt.Errorf("remove_validators: validator %s is not defined", validator)
+		}
+		delete(merged.Validators, validator)
+		delete(origin.Validators, validator)
+	}
+	for _, operator := range child.RemoveOperators {
+		if _, exists := merged.Operators[operator]; !exists {
+			return parent, fmt.Errorf("remove_operators: operator %s is not defined", operator)
+		}
+		delete(merged.Operators, operator)
+		delete(origin.Operators, operator)
+	}
+	for _, flag := range child.RemoveFlags {
+		switch flag {
+		case "required":
+			merged.IsRequired = false
+		case "closed":
+			merged.Closed = false
+		default:
+			return parent, fmt.Errorf("remove_flags: unknown flag %s", flag)
+		}
+	}
+	origin.Source = childOrigin.Source
+	return merged, nil
+}
+
+func mergeProperty(name string, parent string, child string, strategy string) (string, error) {
+	if child == "" || parent == "" || child == parent {
+		if child != "" {
+			return child, nil
+		}
+		return parent, nil
+	}
+	switch strategy {
+	case ConflictKeepParent:
+		return parent, nil
+	case ConflictError:
+		return parent, fmt.Errorf("%s is already defined as %s", name, parent)
+	}
+	return child, nil
+}
+
+// mergeFlag keeps the flag of the parent when the child does not set it
+func mergeFlag(name string, parent *bool, child *bool, strategy string) (*bool, error) {
+	if child == nil || parent == nil || *child == *parent {
+		if child != nil {
+			return child, nil
+		}
+		return parent, nil
+	}
+	switch strategy {
+	case ConflictKeepParent:
+		return parent, nil
+	case ConflictError:
+		return parent, fmt.Errorf("%s is already defined as %t", name, *parent)
+	}
+	return child, nil
+}
+
+func mergeConstants(kind string, parent map[string]Constant, child map[string]Constant, strategy string, origins map[string]string, childOrigins map[string]string) (map[string]Constant, error) {
+	if len(child) == 0 {
+		return parent, nil
+	}
+	merged := copyConstants(parent)
+	if merged == nil {
+		merged = make(map[string]Constant)
+	}
+	for _, name := range sortedConstants(child) {
+		constant := child[name]
+		existing, exists := merged[name]
+		if exists && !reflect.DeepEqual(existing, constant) {
+			switch strategy {
+			case ConflictKeepParent:
+				continue
+			case ConflictError:
+				return nil, fmt.Errorf("%s %s is already defined", kind, name)
+			case ConflictMerge:
+				constant = mergeConstant(existing, constant)
+			}
+		}
+		merged[name] = constant
+		origins[name] = childOrigins[name]
+	}
+	return merged, nil
+}
+
+// mergeConstant keeps the attributes and messages of the parent which are not set by the child
+func mergeConstant(parent Constant, child Constant) Constant {
+	// merging the maps never conflicts
+	attributes, _ := mergeMaps("attributes", parent.Attributes, child.Attributes, ConflictMerge)
+	l10n, _ := mergeMaps("l10n", parent.L10n, child.L10n, ConflictMerge)
+	merged := Constant{
+		Attributes: attributes,
+		Error:      parent.Error,
+		L10n:       l10n,
+		Priority:   parent.Priority,
+	}
+	if child.Priority != 0 {
+		merged.Priority = child.Priority
+	}
+	if child.Error != "" {
+		merged.Error = child.Error
+	}
+	merged.When = parent.When
+	if child.When != nil {
+		merged.When = child.When
+	}
+	return merged
+}
+
+func mergeMaps(name string, parent map[string]interface{}, child map[string]interface{}, strategy string) (map[string]interface{}, error) {
+	if len(child) == 0 {
+		return parent, nil
+	}
+	if len(parent) == 0 {
+		return child, nil
+	}
+	merged := make(map[string]interface{}, len(parent)+len(child))
+	for key, value := range parent {
+		merged[key] = value
+	}
+	var keys []string
+	for key := range child {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := child[key]
+		if existing, exists := merged[key]; exists && !reflect.DeepEqual(existing, value) {
+			switch strategy {
+			case ConflictKeepParent:
+				continue
+			case ConflictError:
+				return nil, fmt.Errorf("%s %s is already defined", name, key)
+			}
+		}
+		merged[key] = value
+	}
+	return merged, nil
+}
@@ -0,0 +1,299 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"os"
+	"sort"
+)
+
+type UntranslatedKeyword struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+}
+
+var ignoredJsonSchemaKeywords = []string{
+	"$schema",
+	"$id",
+	"$comment",
+	"title",
+	"description",
+	"type",
+	"properties",
+	"items",
+	"required",
+	"examples",
+	"default",
+	ExtensionVersion,
+	ExtensionName,
+	ExtensionValidators,
+	ExtensionOperators,
+	ExtensionDependsOn,
+	ExtensionL10n,
+	ExtensionAdditionalInformation,
+	ExtensionWhen,
+}
+
+var jsonSchemaFormats = map[string]string{
+	"email":     "IsEmail",
+	"uri":       "IsURL",
+	"url":       "IsURL",
+	"uuid":      "IsValidUuid",
+	"date":      "IsValidDate",
+	"date-time": "IsValidDate",
+}
+
+func (s *Schematics) LoadJsonSchemaDocument(path string) ([]UntranslatedKeyword, error) {
+	s.Configs()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		s.Logging.ERROR("Failed to load json schema document", err)
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(content, &doc)
+	if err != nil {
+		s.Logging.ERROR("Failed to unmarshall json schema document", err)
+		return nil, err
+	}
+	return s.LoadJsonSchemaMap(doc)
+}
+
+func (s *Schematics) LoadJsonSchemaMap(doc map[string]interface{}) ([]UntranslatedKeyword, error) {
+	if doc == nil {
+		return nil, errors.New("json schema document is empty")
+	}
+	if s.Separator == "" {
+		s.Separator = "."
+	}
+	schema, untranslated := JsonSchemaToSchema(doc, s.Separator)
+	s.Logging.DEBUG("Schema Loaded From JSON Schema: ", schema)
+	for _, keyword := range untranslated {
+		s.Logging.DEBUG("keyword could not be translated:", keyword.Path, keyword.Keyword)
+	}
+	s.loadSchema(*schema)
+	return untranslated, nil
+}
+
+// JsonSchemaToSchema flattens the json schema document into target keys,
+// keywords without an equivalent validator are returned as untranslated
+func JsonSchemaToSchema(doc map[string]interface{}, separator string) (*Schema, []UntranslatedKeyword) {
+	if separator == "" {
+		separator = "."
+	}
+	var schema Schema
+	schema.Fields = make(map[TargetKey]Field)
+	if version, ok := doc[ExtensionVersion].(string); ok {
+		schema.Version = version
+	}
+	var untranslated []UntranslatedKeyword
+	importJsonSchemaNode(doc, "", "", false, separator, &schema, &untranslated)
+	return &schema, untranslated
+}
+
+func importJsonSchemaNode(node map[string]interface{}, target string, pointer string, required bool, separator string, schema *Schema, untranslated *[]UntranslatedKeyword) {
+	var field Field
+	field.IsRequired = required
+	field.Validators = make(map[string]Constant)
+	hasRules := required
+
+	schemaType, nullable := jsonSchemaType(node["type"])
+	if nullable {
+		field.Nullable = &nullable
+		hasRules = true
+	}
+	switch schemaType {
+	case utils.TypeString:
+		field.Validators["IsString"] = Constant{}
+	case utils.TypeNumber:
+		field.Validators["IsNumber"] = Constant{}
+	case utils.TypeInteger:
+		field.Validators["IsInteger"] = Constant{}
+	}
+
+	var keywords []string
+	for keyword := range node {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		value := node[keyword]
+		translated := true
+		switch keyword {
+		case "enum":
+			translated = isStringList(value)
+			if translated {
+				field.Validators["StringTakenFromOptions"] = Constant{Attributes: map[string]interface{}{"options": value}}
+			}
+		case "format":
+			name, ok := jsonSchemaFormats[asString(value)]
+			translated = ok
+			if ok {
+				field.Validators[name] = Constant{}
+			}
+		case "pattern":
+			field.Validators["MatchRegex"] = Constant{Attributes: map[string]interface{}{"regex": value}}
+		case "minLength":
+			field.Validators["MinLengthAllowed"] = Constant{Attributes: map[string]interface{}{"min": value}}
+		case "maxLength":
+			field.Validators["MaxLengthAllowed"] = Constant{Attributes: map[string]interface{}{"max": value}}
+		case "minimum":
+			field.Validators["MinAllowed"] = Constant{Attributes: map[string]interface{}{"min": value}}
+		case "maximum":
+			field.Validators["MaxAllowed"] = Constant{Attributes: map[string]interface{}{"max": value}}
+		case "minItems":
+			field.Validators["ArrayLengthMin"] = Constant{Attributes: map[string]interface{}{"min": value}}
+		case "maxItems":
+			field.Validators["ArrayLengthMax"] = Constant{Attributes: map[string]interface{}{"max": value}}
+		case "uniqueItems":
+			if value == true {
+				field.Validators["ArrayUniqueItems"] = Constant{}
+			}
+		case "contains":
+			contains, _ := value.(map[string]interface{})
+			expected, ok := contains["const"]
+			translated = ok && len(contains) == 1
+			if translated {
+				field.Validators["ArrayContains"] = Constant{Attributes: map[string]interface{}{"value": expected}}
+			}
+		case "minProperties":
+			field.Validators["ObjectPropertiesMin"] = Constant{Attributes: map[string]interface{}{"min": value}}
+		case "maxProperties":
+			field.Validators["ObjectPropertiesMax"] = Constant{Attributes: map[string]interface{}{"max": value}}
+		case "additionalProperties":
+			// the root object is closed by Schematics.Strict
+			translated = value == false && target != ""
+			field.Closed = translated
+		default:
+			translated = utils.StringInStrings(keyword, ignoredJsonSchemaKeywords)
+		}
+		if !translated {
+			*untranslated = append(*untranslated, UntranslatedKeyword{Path: pointer, Keyword: keyword})
+		}
+	}
+	if len(field.Validators) > 0 || field.Closed {
+		hasRules = true
+	}
+
+	if title, ok := node["title"].(string); ok {
+		field.DisplayName = title
+		field.Name = title
+	}
+	if name, ok := node[ExtensionName].(string); ok {
+		field.Name = name
+	}
+	if description, ok := node["description"].(string); ok {
+		field.Description = description
+	}
+	if schemaType != "" {
+		field.Type = schemaType
+	}
+	if value, ok := node["default"]; ok && value != nil {
+		field.Default = value
+		hasRules = true
+	}
+	if decodeExtension(node[ExtensionValidators], &field.Validators) {
+		hasRules = true
+	}
+	if decodeExtension(node[ExtensionOperators], &field.Operators) {
+		hasRules = true
+	}
+	if decodeExtension(node[ExtensionDependsOn], &field.DependsOn) {
+		hasRules = true
+	}
+	if decodeExtension(node[ExtensionWhen], &field.When) {
+		hasRules = true
+	}
+	decodeExtension(node[ExtensionL10n], &field.L10n)
+	decodeExtension(node[ExtensionAdditionalInformation], &field.AdditionalInformation)
+
+	if target != "" && hasRules {
+		schema.Fields[TargetKey(target)] = field
+	}
+
+	var requiredList []string
+	if keys, ok := node["required"].([]interface{}); ok {
+		for _, key := range keys {
+			requiredList = append(requiredList, asString(key))
+		}
+	} else if keys, ok := node["required"].([]string); ok {
+		requiredList = keys
+	}
+	properties, _ := node["properties"].(map[string]interface{})
+	requiredKeys := map[string]bool{}
+	for i, key := range requiredList {
+		requiredKeys[key] = true
+		// a required key without a schema has no target to be required on
+		if _, ok := properties[key].(map[string]interface{}); !ok {
+			*untranslated = append(*untranslated, UntranslatedKeyword{Path: fmt.Sprintf("%s/required/%d", pointer, i), Keyword: "required"})
+		}
+	}
+	if properties != nil {
+		for key, property := range properties {
+			if child, ok := property.(map[string]interface{}); ok {
+				importJsonSchemaNode(child, joinTarget(target, key, separator), pointer+"/properties/"+utils.EscapeJsonPointer(key), requiredKeys[key], separator, schema, untranslated)
+			}
+		}
+	}
+	if items, ok := node["items"].(map[string]interface{}); ok {
+		importJsonSchemaNode(items, joinTarget(target, "*", separator), pointer+"/items", false, separator, schema, untranslated)
+	}
+}
+
+func jsonSchemaType(value interface{}) (string, bool) {
+	switch t := value.(type) {
+	case string:
+		return t, false
+	case []interface{}:
+		schemaType := ""
+		nullable := false
+		for _, item := range t {
+			if asString(item) == "null" {
+				nullable = true
+			} else if schemaType == "" {
+				schemaType = asString(item)
+			}
+		}
+		return schemaType, nullable
+	}
+	return "", false
+}
+
+func decodeExtension(value interface{}, into interface{}) bool {
+	if value == nil {
+		return false
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(content, into) == nil
+}
+
+func isStringList(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		_, ok = value.([]string)
+		return ok
+	}
+	for _, item := range list {
+		if _, ok := item.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func asString(value interface{}) string {
+	str, _ := value.(string)
+	return str
+}
+
+func joinTarget(prefix string, key string, separator string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + separator + key
+}
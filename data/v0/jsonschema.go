@@ -0,0 +1,309 @@
+package v0
+
+import (
+	"encoding/json"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"sort"
+	"strings"
+)
+
+const JsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	ExtensionVersion               = "x-schematics-version"
+	ExtensionName                  = "x-schematics-name"
+	ExtensionValidators            = "x-schematics-validators"
+	ExtensionOperators             = "x-schematics-operators"
+	ExtensionDependsOn             = "x-schematics-depends-on"
+	ExtensionL10n                  = "x-schematics-l10n"
+	ExtensionAdditionalInformation = "x-schematics-additional-information"
+	ExtensionWhen                  = "x-schematics-when"
+)
+
+func (s *Schematics) ToJsonSchema() map[string]interface{} {
+	doc := FieldsToJsonSchema(s.Schema.Fields, s.Separator)
+	doc["$schema"] = JsonSchemaDialect
+	if s.Schema.Version != "" {
+		doc[ExtensionVersion] = s.Schema.Version
+	}
+	return doc
+}
+
+func (s *Schematics) ExportJsonSchema() ([]byte, error) {
+	return json.MarshalIndent(s.ToJsonSchema(), "", "  ")
+}
+
+// FieldsToJsonSchema nests the flat target keys into a json schema object,
+// validators without an equivalent keyword are kept in the vendor extensions, and so are the rules with a when condition
+// so the document never rejects the data they skip
+func FieldsToJsonSchema(fields map[TargetKey]Field, separator string) map[string]interface{} {
+	if separator == "" {
+		separator = "."
+	}
+	var targets []string
+	for target := range fields {
+		targets = append(targets, string(target))
+	}
+	sort.Strings(targets)
+
+	root := map[string]interface{}{"type": utils.TypeObject}
+	for _, target := range targets {
+		field := fields[TargetKey(target)]
+		segments := strings.Split(target, separator)
+		node := root
+		for i, segment := range segments {
+			isLast := i == len(segments)-1
+			var child map[string]interface{}
+			if segment == "*" {
+				child = childSchema(node, "items")
+				if isLast && field.Required() && field.When == nil {
+					// the minItems of an ArrayLengthMin is a float64, it is only raised when it is below 1
+					switch minItems := node["minItems"].(type) {
+					case float64:
+						if minItems < 1 {
+							node["minItems"] = 1
+						}
+					case int:
+						if minItems < 1 {
+							node["minItems"] = 1
+						}
+					default:
+						node["minItems"] = 1
+					}
+				}
+			} else {
+				properties, ok := node["properties"].(map[string]interface{})
+				if !ok {
+					properties = make(map[string]interface{})
+					node["properties"] = properties
+				}
+				child = childSchema(properties, segment)
+				if isLast && field.Required() && field.When == nil {
+					addRequired(node, segment)
+				}
+			}
+			if !isLast {
+				if _, ok := child["type"]; !ok {
+					if segments[i+1] == "*" {
+						child["type"] = utils.TypeArray
+					} else {
+						child["type"] = utils.TypeObject
+					}
+				}
+			}
+			node = child
+		}
+		fieldToJsonSchema(node, field)
+	}
+	return root
+}
+
+func FieldToJsonSchema(field Field) map[string]interface{} {
+	node := make(map[string]interface{})
+	fieldToJsonSchema(node, field)
+	return node
+}
+
+func fieldToJsonSchema(node map[string]interface{}, field Field) {
+	if field.DisplayName != "" {
+		node["title"] = field.DisplayName
+		if field.Name != "" && field.Name != field.DisplayName {
+			node[ExtensionName] = field.Name
+		}
+	} else if field.Name != "" {
+		node["title"] = field.Name
+	}
+	if field.Description != "" {
+		node["description"] = field.Description
+	}
+
+	conditional := field.When != nil
+	switch {
+	case conditional:
+	case field.Type == utils.TypeDate:
+		// dates can be date-times or plain dates, so no format matches them
+		node["type"] = utils.TypeString
+	case utils.StringInStrings(field.Type, []string{utils.TypeString, utils.TypeNumber, utils.TypeInteger, utils.TypeBoolean, utils.TypeArray, utils.TypeObject}):
+		node["type"] = field.Type
+	}
+
+	// the validators are translated in the order they run, a later validator overwrites the keywords of an earlier one
+	extensions := make(map[string]Constant)
+	if conditional && field.Required() {
+		extensions["IsRequired"] = Constant{}
+	}
+	for _, name := range field.OrderedValidators() {
+		constant := field.Validators[name]
+		if utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators) {
+			continue
+		}
+		if conditional || constant.When != nil {
+			extensions[name] = constant
+			continue
+		}
+		mapped := validatorToJsonSchema(node, name, constant.Attributes)
+		if !mapped || constant.Error != "" || len(constant.L10n) > 0 {
+			extensions[name] = constant
+		}
+	}
+	if len(extensions) > 0 {
+		node[ExtensionValidators] = extensions
+	}
+	if field.Default != nil {
+		node["default"] = field.Default
+	}
+	if field.Closed && !conditional {
+		node["additionalProperties"] = false
+	}
+	if conditional {
+		node[ExtensionWhen] = field.When
+	}
+	if field.Nullable != nil && *field.Nullable {
+		if schemaType, ok := node["type"].(string); ok {
+			node["type"] = []interface{}{schemaType, "null"}
+		}
+	}
+	if len(field.Operators) > 0 {
+		node[ExtensionOperators] = field.Operators
+	}
+	if len(field.DependsOn) > 0 {
+		node[ExtensionDependsOn] = field.DependsOn
+	}
+	if len(field.L10n) > 0 {
+		node[ExtensionL10n] = field.L10n
+	}
+	if len(field.AdditionalInformation) > 0 {
+		node[ExtensionAdditionalInformation] = field.AdditionalInformation
+	}
+}
+
+func validatorToJsonSchema(node map[string]interface{}, name string, attributes map[string]interface{}) bool {
+	switch name {
+	case "IsString":
+		setSchemaType(node, utils.TypeString)
+	case "IsNumber":
+		setSchemaType(node, utils.TypeNumber)
+	case "IsInteger":
+		setSchemaType(node, utils.TypeInteger)
+	case "NotEmpty":
+		setSchemaType(node, utils.TypeString)
+		node["pattern"] = `\S`
+	case "IsEmail":
+		setSchemaType(node, utils.TypeString)
+		node["format"] = "email"
+	case "IsURL":
+		setSchemaType(node, utils.TypeString)
+		node["format"] = "uri"
+	case "IsValidUuid":
+		setSchemaType(node, utils.TypeString)
+		node["format"] = "uuid"
+	case "IsValidDate":
+		setSchemaType(node, utils.TypeString)
+		return false
+	case "StringTakenFromOptions":
+		return copyAttributes(node, attributes, utils.TypeString, map[string]string{"options": "enum"})
+	case "MaxLengthAllowed":
+		return copyAttributes(node, attributes, utils.TypeString, map[string]string{"max": "maxLength"})
+	case "MinLengthAllowed":
+		return copyAttributes(node, attributes, utils.TypeString, map[string]string{"min": "minLength"})
+	case "InBetweenLengthAllowed":
+		return copyAttributes(node, attributes, utils.TypeString, map[string]string{"min": "minLength", "max": "maxLength"})
+	case "MatchRegex":
+		return copyAttributes(node, attributes, utils.TypeString, map[string]string{"regex": "pattern"})
+	case "LIKE":
+		pattern, ok := attributes["pattern"].(string)
+		if !ok {
+			return false
+		}
+		setSchemaType(node, utils.TypeString)
+		node["pattern"] = validators.LikeToRegex(pattern)
+	case "MaxAllowed":
+		return copyAttributes(node, attributes, utils.TypeNumber, map[string]string{"max": "maximum"})
+	case "MinAllowed":
+		return copyAttributes(node, attributes, utils.TypeNumber, map[string]string{"min": "minimum"})
+	case "InBetween":
+		return copyAttributes(node, attributes, utils.TypeNumber, map[string]string{"min": "minimum", "max": "maximum"})
+	case "ArrayLengthMax":
+		return copyAttributes(node, attributes, utils.TypeArray, map[string]string{"max": "maxItems"})
+	case "ArrayLengthMin":
+		return copyAttributes(node, attributes, utils.TypeArray, map[string]string{"min": "minItems"})
+	case "ArrayUniqueItems":
+		if key, _ := attributes["key"].(string); key != "" {
+			return false
+		}
+		setSchemaType(node, utils.TypeArray)
+		node["uniqueItems"] = true
+	case "ArrayContains":
+		value, ok := attributes["value"]
+		if !ok {
+			return false
+		}
+		setSchemaType(node, utils.TypeArray)
+		node["contains"] = map[string]interface{}{"const": value}
+	case "ObjectPropertiesMax":
+		return copyAttributes(node, attributes, utils.TypeObject, map[string]string{"max": "maxProperties"})
+	case "ObjectPropertiesMin":
+		return copyAttributes(node, attributes, utils.TypeObject, map[string]string{"min": "minProperties"})
+	case "StringsTakenFromOptions":
+		options, ok := attributes["options"]
+		if !ok {
+			return false
+		}
+		setSchemaType(node, utils.TypeArray)
+		items := childSchema(node, "items")
+		setSchemaType(items, utils.TypeString)
+		items["enum"] = options
+	default:
+		return false
+	}
+	return true
+}
+
+func copyAttributes(node map[string]interface{}, attributes map[string]interface{}, schemaType string, keywords map[string]string) bool {
+	for attr := range keywords {
+		if _, ok := attributes[attr]; !ok {
+			return false
+		}
+	}
+	setSchemaType(node, schemaType)
+	for attr, keyword := range keywords {
+		node[keyword] = attributes[attr]
+	}
+	return true
+}
+
+func setSchemaType(node map[string]interface{}, schemaType string) {
+	if _, ok := node["type"]; !ok {
+		node["type"] = schemaType
+	}
+}
+
+func childSchema(parent map[string]interface{}, key string) map[string]interface{} {
+	child, ok := parent[key].(map[string]interface{})
+	if !ok {
+		child = make(map[string]interface{})
+		parent[key] = child
+	}
+	return child
+}
+
+func addRequired(node map[string]interface{}, key string) {
+	required, _ := node["required"].([]string)
+	if !utils.StringInStrings(key, required) {
+		node["required"] = append(required, key)
+	}
+}
+
+// Required checks the required flag and the required validators of the field
+func (f *Field) Required() bool {
+	if f.IsRequired {
+		return true
+	}
+	for name := range f.Validators {
+		if utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators) {
+			return true
+		}
+	}
+	return false
+}
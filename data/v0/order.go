@@ -0,0 +1,94 @@
+package v0
+
+import (
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"sort"
+	"strings"
+)
+
+// OrderedValidators returns the names of the validators in the order they run, the required validators and the type checks
+// come first, then the validators with a higher priority and then the order of the schema file,
+// validators which are not in ValidatorOrder are sorted by name
+func (f *Field) OrderedValidators() []string {
+	position := make(map[string]int)
+	for i, name := range f.ValidatorOrder {
+		if _, exists := position[name]; !exists {
+			position[name] = i
+		}
+	}
+	names := make([]string, 0, len(f.Validators))
+	for name := range f.Validators {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := names[i], names[j]
+		if rankA, rankB := validatorRank(a), validatorRank(b); rankA != rankB {
+			return rankA < rankB
+		}
+		if priorityA, priorityB := f.Validators[a].Priority, f.Validators[b].Priority; priorityA != priorityB {
+			return priorityA > priorityB
+		}
+		positionA, declaredA := position[a]
+		positionB, declaredB := position[b]
+		if declaredA != declaredB {
+			return declaredA
+		}
+		if declaredA && positionA != positionB {
+			return positionA < positionB
+		}
+		return a < b
+	})
+	return names
+}
+
+// MarshalJSON writes the validators in the order they are declared so an exported schema keeps its ValidatorOrder
+func (f Field) MarshalJSON() ([]byte, error) {
+	type plain Field
+	return utils.MarshalJSON(struct {
+		plain
+		Validators utils.OrderedObject `json:"validators,omitempty"`
+	}{plain(f), utils.NewOrderedObject(f.Validators, f.ValidatorOrder)})
+}
+
+func validatorRank(name string) int {
+	switch {
+	case utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators):
+		return 0
+	case utils.StringInStrings(name, utils.TypeValidators):
+		return 1
+	}
+	return 2
+}
+
+// applyKeyOrder keeps the order of the validators of the fields, the groups and the validator sets
+func (s *Schema) applyKeyOrder(order utils.KeyOrder) {
+	for target, field := range s.Fields {
+		field.ValidatorOrder = order.Keys("fields", string(target), "validators")
+		s.Fields[target] = field
+	}
+	for name, group := range s.Groups {
+		for target, field := range group {
+			field.ValidatorOrder = order.Keys("groups", name, string(target), "validators")
+			group[target] = field
+		}
+	}
+	for name := range s.ValidatorSets {
+		if s.setOrders == nil {
+			s.setOrders = make(map[string][]string)
+		}
+		s.setOrders[name] = order.Keys("validator_sets", name)
+	}
+}
+
+// mergeOrder appends the orders into a new slice without duplicates
+func mergeOrder(orders ...[]string) []string {
+	var merged []string
+	for _, order := range orders {
+		for _, name := range order {
+			if !utils.StringInStrings(name, merged) {
+				merged = append(merged, name)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,310 @@
+package v0
+
+import (
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type Include struct {
+	Ref   string `json:"$ref"`
+	Mount string `json:"mount"`
+}
+
+type schemaResolver struct {
+	readFile   func(name string) ([]byte, error)
+	join       func(base string, ref string) string
+	separator  string
+	strategy   string
+	rootFormat string
+	loading    []string
+	resolved   map[string]*Schema
+}
+
+func newFileResolver(separator string, strategy string) *schemaResolver {
+	return &schemaResolver{
+		readFile: os.ReadFile,
+		join: func(base string, ref string) string {
+			if filepath.IsAbs(ref) {
+				return filepath.Clean(ref)
+			}
+			return filepath.Join(filepath.Dir(base), ref)
+		},
+		separator: separator,
+		strategy:  strategy,
+		resolved:  make(map[string]*Schema),
+	}
+}
+
+func newFSResolver(fsys fs.FS, separator string, strategy string) *schemaResolver {
+	return &schemaResolver{
+		readFile: func(name string) ([]byte, error) {
+			return fs.ReadFile(fsys, name)
+		},
+		join: func(base string, ref string) string {
+			return path.Join(path.Dir(base), ref)
+		},
+		separator: separator,
+		strategy:  strategy,
+		resolved:  make(map[string]*Schema),
+	}
+}
+
+// resolve loads the schema file, mounts all of its includes and validator sets and applies the parent schema,
+// the files which are being loaded are tracked to detect reference cycles
+func (r *schemaResolver) resolve(name string) (*Schema, error) {
+	if schema, exists := r.resolved[name]; exists {
+		return schema, nil
+	}
+	for _, loading := range r.loading {
+		if loading == name {
+			return nil, fmt.Errorf("reference cycle detected: %s -> %s", strings.Join(r.loading, " -> "), name)
+		}
+	}
+	isRoot := len(r.loading) == 0
+	r.loading = append(r.loading, name)
+	defer func() {
+		r.loading = r.loading[:len(r.loading)-1]
+	}()
+
+	content, err := r.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+	format := utils.DetectFormat(name, content)
+	if isRoot && r.rootFormat != "" {
+		format = r.rootFormat
+	}
+	var schema Schema
+	err = utils.UnmarshalSchema(content, format, &schema)
+	if err != nil {
+		if isRoot {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	order, err := utils.ReadKeyOrder(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	schema.applyKeyOrder(order)
+	err = r.resolveReferences(name, &schema)
+	if err != nil {
+		return nil, err
+	}
+	r.resolved[name] = &schema
+	return &schema, nil
+}
+
+func (r *schemaResolver) resolveReferences(name string, schema *Schema) error {
+	for groupName, group := range schema.Groups {
+		for target, field := range group {
+			_, err := r.resolveValidators(name, schema, &field)
+			if err != nil {
+				return fmt.Errorf("group %s, field %s: %w", groupName, target, err)
+			}
+			group[target] = field
+		}
+	}
+
+	fields := make(map[TargetKey]Field)
+	origins := make(map[TargetKey]FieldOrigin)
+	for _, include := range schema.Includes {
+		group, source, groupOrigins, err := r.lookupGroup(name, schema, include.Ref)
+		if err != nil {
+			return err
+		}
+		for target, field := range group {
+			field.DependsOn = r.mountDependsOn(include.Mount, field.DependsOn, group)
+			field.When = r.mountCondition(include.Mount, field.When, group)
+			field.Validators = r.mountValidators(include.Mount, copyConstants(field.Validators), group)
+			field.Operators = copyConstants(field.Operators)
+			mounted := TargetKey(r.mount(include.Mount, string(target)))
+			fields[mounted] = field
+			if origin, exists := groupOrigins[target]; exists {
+				origins[mounted] = origin.copy()
+			} else {
+				origins[mounted] = newFieldOrigin(source, field)
+			}
+		}
+	}
+	for target, field := range schema.Fields {
+		refs, err := r.resolveValidators(name, schema, &field)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", target, err)
+		}
+		fields[target] = field
+		origin := newFieldOrigin(name, field)
+		for validator, ref := range refs {
+			origin.Validators[validator] = ref
+		}
+		origins[target] = origin
+	}
+	schema.Fields = fields
+	schema.origins = origins
+	schema.Includes = nil
+	if schema.Extends != "" {
+		return r.extend(name, schema)
+	}
+	return nil
+}
+
+// lookupGroup returns the included fields, the name of the file or group they come from
+// and the origins of the fields when a whole file is included
+func (r *schemaResolver) lookupGroup(name string, schema *Schema, ref string) (map[TargetKey]Field, string, map[TargetKey]FieldOrigin, error) {
+	file, fragment := splitRef(ref)
+	source := schema
+	sourceName := name
+	if file != "" {
+		var err error
+		sourceName = r.join(name, file)
+		source, err = r.resolve(sourceName)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+	if fragment == "" {
+		if file == "" {
+			return nil, "", nil, fmt.Errorf("invalid include reference %s", ref)
+		}
+		return source.Fields, sourceName, source.origins, nil
+	}
+	group, exists := source.Groups[fragment]
+	if !exists {
+		return nil, "", nil, fmt.Errorf("field group %s is not defined", ref)
+	}
+	return group, sourceName + "#" + fragment, nil, nil
+}
+
+// resolveValidators adds the referenced validator sets to the field and returns the set every added validator came from
+func (r *schemaResolver) resolveValidators(name string, schema *Schema, field *Field) (map[string]string, error) {
+	if len(field.ValidatorsRef) == 0 {
+		return nil, nil
+	}
+	validators := make(map[string]Constant)
+	refs := make(map[string]string)
+	var orders [][]string
+	for _, ref := range field.ValidatorsRef {
+		file, fragment := splitRef(ref)
+		source := schema
+		sourceName := name
+		if file != "" {
+			var err error
+			sourceName = r.join(name, file)
+			source, err = r.resolve(sourceName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		set, exists := source.ValidatorSets[fragment]
+		if !exists {
+			return nil, fmt.Errorf("validator set %s is not defined", ref)
+		}
+		for validator, constant := range set {
+			validators[validator] = constant
+			refs[validator] = sourceName + "#" + fragment
+		}
+		order, exists := source.setOrders[fragment]
+		if !exists {
+			order = sortedConstants(set)
+		}
+		orders = append(orders, order)
+	}
+	for validator, constant := range field.Validators {
+		validators[validator] = constant
+		delete(refs, validator)
+	}
+	field.Validators = validators
+	field.ValidatorOrder = mergeOrder(append(orders, field.ValidatorOrder)...)
+	field.ValidatorsRef = nil
+	return refs, nil
+}
+
+func (r *schemaResolver) mount(mount string, target string) string {
+	if mount == "" {
+		return target
+	}
+	return mount + r.separator + target
+}
+
+func (r *schemaResolver) mountDependsOn(mount string, dependsOn []string, group map[TargetKey]Field) []string {
+	if mount == "" || len(dependsOn) == 0 {
+		return dependsOn
+	}
+	var mounted []string
+	for _, d := range dependsOn {
+		mounted = append(mounted, r.mountReference(mount, d, group))
+	}
+	return mounted
+}
+
+// mountReference mounts the keys which are targets of the group, like depends on, conditions and the field of
+// the cross field validators point at the mounted targets
+func (r *schemaResolver) mountReference(mount string, key string, group map[TargetKey]Field) string {
+	if _, exists := group[TargetKey(key)]; exists && mount != "" {
+		return r.mount(mount, key)
+	}
+	return key
+}
+
+// mountCondition returns a mounted copy, the condition of the group is shared by every include
+func (r *schemaResolver) mountCondition(mount string, condition *Condition, group map[TargetKey]Field) *Condition {
+	if condition == nil || mount == "" {
+		return condition
+	}
+	mounted := *condition
+	mounted.Field = r.mountReference(mount, condition.Field, group)
+	mounted.All = nil
+	for i := range condition.All {
+		mounted.All = append(mounted.All, *r.mountCondition(mount, &condition.All[i], group))
+	}
+	mounted.Any = nil
+	for i := range condition.Any {
+		mounted.Any = append(mounted.Any, *r.mountCondition(mount, &condition.Any[i], group))
+	}
+	mounted.Not = r.mountCondition(mount, condition.Not, group)
+	return &mounted
+}
+
+func (r *schemaResolver) mountValidators(mount string, constants map[string]Constant, group map[TargetKey]Field) map[string]Constant {
+	if mount == "" {
+		return constants
+	}
+	for name, constant := range constants {
+		constant.When = r.mountCondition(mount, constant.When, group)
+		if field, ok := constant.Attributes[validators.FieldAttribute].(string); ok {
+			attributes := make(map[string]interface{}, len(constant.Attributes))
+			for key, value := range constant.Attributes {
+				attributes[key] = value
+			}
+			attributes[validators.FieldAttribute] = r.mountReference(mount, field, group)
+			constant.Attributes = attributes
+		}
+		constants[name] = constant
+	}
+	return constants
+}
+
+func splitRef(ref string) (string, string) {
+	parts := strings.SplitN(ref, "#", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+func copyConstants(constants map[string]Constant) map[string]Constant {
+	if constants == nil {
+		return nil
+	}
+	copied := make(map[string]Constant, len(constants))
+	for name, constant := range constants {
+		copied[name] = constant
+	}
+	return copied
+}
@@ -7,46 +7,85 @@ import (
 	"github.com/ashbeelghouri/jsonschematics/operators"
 	"github.com/ashbeelghouri/jsonschematics/utils"
 	"github.com/ashbeelghouri/jsonschematics/validators"
+	"io"
+	"io/fs"
 	"log"
-	"os"
 	"strings"
 )
 
 type TargetKey string
 
 type Schematics struct {
-	Schema     Schema
-	Validators validators.Validators
-	Operators  operators.Operators
-	Separator  string
-	ArrayIdKey string
-	Locale     string
+	Schema           Schema
+	Validators       validators.Validators
+	Operators        operators.Operators
+	Separator        string
+	ArrayIdKey       string
+	Locale           string
+	ConflictStrategy string
+	// ReportAllErrors runs every validator of a value and reports all the failures instead of the first one
+	ReportAllErrors bool
+	// Coerce converts the strings to the type of their field before the validators and the operators run,
+	// the operations write the converted values
+	Coerce bool
+	// Strict reports the keys of the data which no target matches, StripUnknown removes them in the operations,
+	// the fields with closed only do it for their own keys
+	Strict       bool
+	StripUnknown bool
+	// DefaultFns are the dynamic defaults in addition to BasicDefaultFns
+	DefaultFns map[string]DefaultFn
 	Logging    utils.Logger
 }
 
 type Schema struct {
-	Version string              `json:"version"`
-	Fields  map[TargetKey]Field `json:"fields"`
+	Version       string                         `json:"version"`
+	Extends       string                         `json:"extends,omitempty"`
+	RemoveFields  []TargetKey                    `json:"remove_fields,omitempty"`
+	Includes      []Include                      `json:"includes,omitempty"`
+	Groups        map[string]map[TargetKey]Field `json:"groups,omitempty"`
+	ValidatorSets map[string]map[string]Constant `json:"validator_sets,omitempty"`
+	Fields        map[TargetKey]Field            `json:"fields"`
+	origins       map[TargetKey]FieldOrigin
+	setOrders     map[string][]string
 }
 
 type Field struct {
-	DependsOn             []string               `json:"depends_on"`
-	DisplayName           string                 `json:"display_name"`
-	Name                  string                 `json:"name"`
-	Type                  string                 `json:"type"`
-	IsRequired            bool                   `json:"required"`
-	Description           string                 `json:"description"`
-	Validators            map[string]Constant    `json:"validators"`
-	Operators             map[string]Constant    `json:"operators"`
-	L10n                  map[string]interface{} `json:"l10n"`
-	AdditionalInformation map[string]interface{} `json:"additional_information"`
+	DependsOn   []string `json:"depends_on,omitempty"`
+	DisplayName string   `json:"display_name,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	IsRequired  bool     `json:"required,omitempty"`
+	// Nullable accepts null and AllowEmpty accepts empty strings, arrays and objects without running the validators,
+	// false rejects them and a required field still has to be present
+	Nullable   *bool `json:"nullable,omitempty"`
+	AllowEmpty *bool `json:"allow_empty,omitempty"`
+	// Closed rejects the keys inside the field which no target matches
+	Closed bool `json:"closed,omitempty"`
+	// Default is set by the operations when the key is absent, a string with the name of a DefaultFn is replaced by its result
+	Default     interface{}         `json:"default,omitempty"`
+	When        *Condition          `json:"when,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Validators  map[string]Constant `json:"validators,omitempty"`
+	// ValidatorOrder is the order in which the validators are declared, it is read from the schema file
+	ValidatorOrder        []string               `json:"-"`
+	ValidatorsRef         []string               `json:"validators_ref,omitempty"`
+	Merge                 string                 `json:"merge,omitempty"`
+	RemoveValidators      []string               `json:"remove_validators,omitempty"`
+	RemoveOperators       []string               `json:"remove_operators,omitempty"`
+	RemoveFlags           []string               `json:"remove_flags,omitempty"`
+	Operators             map[string]Constant    `json:"operators,omitempty"`
+	L10n                  map[string]interface{} `json:"l10n,omitempty"`
+	AdditionalInformation map[string]interface{} `json:"additional_information,omitempty"`
 	logging               utils.Logger
 }
 
 type Constant struct {
-	Attributes map[string]interface{} `json:"attributes"`
-	Error      string                 `json:"error"`
-	L10n       map[string]interface{} `json:"l10n"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	L10n       map[string]interface{} `json:"l10n,omitempty"`
+	When       *Condition             `json:"when,omitempty"`
+	// Priority runs the validators with a higher priority first
+	Priority int `json:"priority,omitempty"`
 }
 
 func (s *Schematics) Configs() {
@@ -61,31 +100,112 @@ func (s *Schematics) Configs() {
 }
 
 func (s *Schematics) LoadJsonSchemaFile(path string) error {
+	return s.loadSchemaFile(path, utils.FormatJson)
+}
+
+func (s *Schematics) LoadYamlSchemaFile(path string) error {
+	return s.loadSchemaFile(path, utils.FormatYaml)
+}
+
+// LoadSchemaFile detects the format of the schema file from the extension or the content
+func (s *Schematics) LoadSchemaFile(path string) error {
+	return s.loadSchemaFile(path, "")
+}
+
+func (s *Schematics) loadSchemaFile(path string, format string) error {
+	s.Configs()
+	if s.Separator == "" {
+		s.Separator = "."
+	}
+	resolver := newFileResolver(s.Separator, s.ConflictStrategy)
+	resolver.rootFormat = format
+	schema, err := resolver.resolve(path)
+	if err != nil {
+		s.Logging.ERROR("Failed to load schema file", err)
+		return err
+	}
+	s.Logging.DEBUG("Schema Loaded From File: ", schema)
+	s.loadSchema(*schema)
+	return nil
+}
+
+// LoadSchemaFS loads the schema file from the file system, references are resolved relative to the schema file
+func (s *Schematics) LoadSchemaFS(fsys fs.FS, name string) error {
 	s.Configs()
-	content, err := os.ReadFile(path)
+	if s.Separator == "" {
+		s.Separator = "."
+	}
+	resolver := newFSResolver(fsys, s.Separator, s.ConflictStrategy)
+	schema, err := resolver.resolve(name)
 	if err != nil {
 		s.Logging.ERROR("Failed to load schema file", err)
 		return err
 	}
+	s.Logging.DEBUG("Schema Loaded From FS: ", schema)
+	s.loadSchema(*schema)
+	return nil
+}
+
+// LoadSchemaReader reads a json or yaml schema, the format is detected from the content when it is empty,
+// references are resolved relative to the working directory like LoadMap
+func (s *Schematics) LoadSchemaReader(r io.Reader, format string) error {
+	s.Configs()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		s.Logging.ERROR("Failed to read schema", err)
+		return err
+	}
+	if format == "" {
+		format = utils.DetectFormat("", content)
+	}
 	var schema Schema
-	err = json.Unmarshal(content, &schema)
+	err = utils.UnmarshalSchema(content, format, &schema)
 	if err != nil {
-		s.Logging.ERROR("Failed to unmarshall schema file", err)
+		s.Logging.ERROR("Invalid Schema", err)
 		return err
 	}
-	s.Logging.DEBUG("Schema Loaded From File: ", schema)
-	s.Schema = schema
-	s.Validators.BasicValidators()
-	s.Operators.LoadBasicOperations()
+	order, err := utils.ReadKeyOrder(content, format)
+	if err != nil {
+		s.Logging.ERROR("Invalid Schema", err)
+		return err
+	}
+	schema.applyKeyOrder(order)
 	if s.Separator == "" {
 		s.Separator = "."
 	}
-	if s.Locale == "" {
-		s.Locale = "en"
+	resolver := newFileResolver(s.Separator, s.ConflictStrategy)
+	err = resolver.resolveReferences("", &schema)
+	if err != nil {
+		s.Logging.ERROR("Failed to resolve the references", err)
+		return err
 	}
+	s.Logging.DEBUG("Schema Loaded From Reader: ", schema)
+	s.loadSchema(schema)
 	return nil
 }
 
+// LoadSchemasFS loads every file of the file system matching the glob pattern, keyed by the path of the file
+func LoadSchemasFS(fsys fs.FS, pattern string) (map[string]*Schematics, error) {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*Schematics)
+	for _, name := range names {
+		var s Schematics
+		if err := s.LoadSchemaFS(fsys, name); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		schemas[name] = &s
+	}
+	return schemas, nil
+}
+
+// Export writes the schema as pretty printed json or yaml
+func (s *Schema) Export(format string) ([]byte, error) {
+	return utils.MarshalSchema(s, format)
+}
+
 func (s *Schematics) LoadMap(schemaMap interface{}) error {
 	JSON, err := json.Marshal(schemaMap)
 	if err != nil {
@@ -98,7 +218,21 @@ func (s *Schematics) LoadMap(schemaMap interface{}) error {
 		s.Logging.ERROR("Invalid Schema", err)
 		return err
 	}
+	if s.Separator == "" {
+		s.Separator = "."
+	}
+	resolver := newFileResolver(s.Separator, s.ConflictStrategy)
+	err = resolver.resolveReferences("", &schema)
+	if err != nil {
+		s.Logging.ERROR("Failed to resolve the references", err)
+		return err
+	}
 	s.Logging.DEBUG("Schema Loaded From MAP: ", schema)
+	s.loadSchema(schema)
+	return nil
+}
+
+func (s *Schematics) loadSchema(schema Schema) {
 	s.Schema = schema
 	s.Validators.BasicValidators()
 	s.Operators.LoadBasicOperations()
@@ -108,51 +242,123 @@ func (s *Schematics) LoadMap(schemaMap interface{}) error {
 	if s.Locale == "" {
 		s.Locale = "en"
 	}
-	return nil
 }
 
+// Validate checks the value on its own, validators with a when condition only run inside ValidateObject
+// where the other values of the document are known
 func (f *Field) Validate(value interface{}, allValidators map[string]validators.Validator, id *string) *errorHandler.Error {
-	var err errorHandler.Error
-	err.Value = value
-	err.ID = id
-	err.Validator = "unknown"
-	if f.Validators == nil {
+	errs := f.validate(value, allValidators, id, nil, false)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &errs[0]
+}
+
+// ValidateAll runs every validator on the value and returns all the failures in the order the validators run
+func (f *Field) ValidateAll(value interface{}, allValidators map[string]validators.Validator, id *string) []errorHandler.Error {
+	return f.validate(value, allValidators, id, nil, true)
+}
+
+// validate stops on the first failure unless all is true
+func (f *Field) validate(value interface{}, allValidators map[string]validators.Validator, id *string, scope *conditionScope, all bool) []errorHandler.Error {
+	var errs []errorHandler.Error
+	newError := func(validator string) errorHandler.Error {
+		var err errorHandler.Error
+		err.Value = value
+		err.ID = id
+		err.Validator = validator
+		return err
+	}
+	// a closed field without validators only describes its keys
+	if f.Validators == nil && !f.Closed {
+		err := newError("unknown")
 		err.AddMessage("en", "no validators defined")
-		return &err
+		return append(errs, err)
 	}
-	for name, constants := range f.Validators {
-		err.Validator = name
-		f.logging.DEBUG("Validator: ", name, constants)
-		if name != "" {
-			f.logging.DEBUG("Name of the validator is not given: ", name)
-			err.Validator = name
+	if value == nil && f.Nullable != nil {
+		if *f.Nullable {
+			return nil
 		}
-		if f.IsRequired && value == nil {
-			err.Validator = "Required"
-			err.AddMessage("en", "this is a required field")
-			f.logging.DEBUG("Field is required but value is null")
-			return &err
+		err := newError("Nullable")
+		err.AddMessage("en", "this field can not be null")
+		return append(errs, err)
+	}
+	if value == nil && f.Required() {
+		err := newError("Required")
+		err.AddMessage("en", "this is a required field")
+		f.logging.DEBUG("Field is required but value is null")
+		return append(errs, err)
+	}
+	// like an absent key, null is not validated when the field is not required
+	if value == nil {
+		return nil
+	}
+	if f.AllowEmpty != nil && utils.IsEmptyValue(value) {
+		if *f.AllowEmpty {
+			return nil
 		}
-
+		err := newError("AllowEmpty")
+		err.AddMessage("en", "this field can not be empty")
+		return append(errs, err)
+	}
+	for _, name := range f.OrderedValidators() {
+		constants := f.Validators[name]
+		f.logging.DEBUG("Validator: ", name, constants)
 		if utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators) {
 			continue
 		}
+		if constants.When != nil && (scope == nil || !constants.When.holds(*scope)) {
+			continue
+		}
 
 		var fn validators.Validator
 		fn, exists := allValidators[name]
 		f.logging.DEBUG("function exists? ", exists)
 		if !exists {
 			f.logging.ERROR("function not found", name)
+			err := newError(name)
 			err.AddMessage("en", "validator not registered")
-			return &err
+			errs = append(errs, err)
+			if !all {
+				return errs
+			}
+			continue
 		}
 
-		fnError := fn(value, constants.Attributes)
+		attributes := constants.Attributes
+		relatedTarget, compares := "", comparesField(attributes)
+		var relatedValue interface{}
+		if compares {
+			// the comparison is skipped without the document or when the other value is missing, required fields report it
+			if scope == nil {
+				continue
+			}
+			relatedTarget = scope.reference(attributes)
+			relatedValue = scope.data[relatedTarget]
+			if relatedValue == nil {
+				continue
+			}
+			attributes = make(map[string]interface{}, len(constants.Attributes)+2)
+			for key, attribute := range constants.Attributes {
+				attributes[key] = attribute
+			}
+			attributes[validators.FieldTargetAttribute] = relatedTarget
+			attributes[validators.FieldValueAttribute] = relatedValue
+		}
+
+		fnError := fn(value, attributes)
 		f.logging.DEBUG("fnError: ", fnError)
 		if fnError != nil {
+			err := newError(name)
+			if compares {
+				err.RelatedTarget = relatedTarget
+				err.RelatedValue = relatedValue
+			}
 			if constants.Error != "" {
 				f.logging.DEBUG("Custom Error is Defined", constants.Error)
 				err.AddMessage("en", constants.Error)
+			} else {
+				err.AddMessage("en", fnError.Error())
 			}
 
 			if f.L10n != nil {
@@ -163,10 +369,13 @@ func (f *Field) Validate(value interface{}, allValidators map[string]validators.
 					}
 				}
 			}
-			return &err
+			errs = append(errs, err)
+			if !all {
+				return errs
+			}
 		}
 	}
-	return nil
+	return errs
 }
 
 func (s *Schematics) makeFlat(data map[string]interface{}) *map[string]interface{} {
@@ -175,6 +384,22 @@ func (s *Schematics) makeFlat(data map[string]interface{}) *map[string]interface
 	return &dMap.Data
 }
 
+// coerceFlat converts the values of the flat data to the type of their field, it returns the values which can not be converted
+func (s *Schematics) coerceFlat(data map[string]interface{}) map[string]error {
+	failures := make(map[string]error)
+	for target, field := range s.Schema.Fields {
+		for key, value := range utils.FindMatchingKeys(data, string(target)) {
+			coerced, err := validators.Coerce(value, field.Type)
+			if err != nil {
+				failures[key] = err
+				continue
+			}
+			data[key] = coerced
+		}
+	}
+	return failures
+}
+
 func (s *Schematics) deflate(data map[string]interface{}) map[string]interface{} {
 	return utils.DeflateMap(data, s.Separator)
 }
@@ -221,20 +446,48 @@ func (s *Schematics) ValidateObject(jsonData *map[string]interface{}, id *string
 		uniqueID = *id
 	}
 	s.Logging.DEBUG("after unique id")
+	var coerceErrors map[string]error
+	if s.Coerce {
+		coerceErrors = s.coerceFlat(flatData)
+		for key, err := range coerceErrors {
+			var coerceError errorHandler.Error
+			coerceError.Validator = "Coerce"
+			coerceError.Value = flatData[key]
+			coerceError.ID = &uniqueID
+			coerceError.AddMessage("en", err.Error())
+			errorMessages.AddError(key, coerceError)
+		}
+	}
+	for _, key := range s.unknownKeys(flatData) {
+		errorMessages.AddError(key, s.unknownKeyError(key, flatData[key], &uniqueID))
+	}
 	var missingFromDependants []string
 	for target, field := range s.Schema.Fields {
 		field.logging = s.Logging
 		baseError.Validator = "is-required"
+		scope := conditionScope{data: flatData, target: string(target), separator: s.Separator}
 		matchingKeys := utils.FindMatchingKeys(flatData, string(target))
+		// the validators of an array or an object get the whole value, the flat data only has the keys nested under it
+		if len(field.Validators) > 0 {
+			for key, value := range utils.FindContainers(flatData, *jsonData, string(target), s.Separator) {
+				if _, exists := matchingKeys[key]; !exists {
+					matchingKeys[key] = value
+				}
+			}
+		}
 		s.Logging.DEBUG("matching keys --> ", matchingKeys)
 		if len(matchingKeys) == 0 {
-			if field.IsRequired {
+			if field.IsRequired && field.When.holds(scope) && !utils.HasNestedKeys(flatData, string(target), s.Separator) {
 				baseError.AddMessage("en", "this field is required")
 				errorMessages.AddError(string(target), baseError)
 			}
 			continue
 		}
 		s.Logging.DEBUG("after is required --> ", matchingKeys)
+		// fields inside arrays check the condition for every item below
+		if !strings.Contains(string(target), "*") && !field.When.holds(scope) {
+			continue
+		}
 		//	check for dependencies
 		if len(field.DependsOn) > 0 {
 			missing := false
@@ -256,11 +509,16 @@ func (s *Schematics) ValidateObject(jsonData *map[string]interface{}, id *string
 		}
 
 		for key, value := range matchingKeys {
-			validationError := field.Validate(value, s.Validators.ValidationFns, &uniqueID)
-			s.Logging.DEBUG(validationError)
-			if validationError != nil {
-				errorMessages.AddError(key, *validationError)
+			scope.key = key
+			if !field.When.holds(scope) {
+				continue
+			}
+			if _, failed := coerceErrors[key]; failed {
+				continue
 			}
+			validationErrors := field.validate(value, s.Validators.ValidationFns, &uniqueID, &scope, s.ReportAllErrors)
+			s.Logging.DEBUG(validationErrors)
+			errorMessages.AddErrors(key, validationErrors)
 		}
 
 	}
@@ -280,12 +538,11 @@ func (s *Schematics) ValidateArray(jsonData []map[string]interface{}) *errorHand
 		var dMap utils.DataMap
 		dMap.FlattenTheMap(d, "", s.Separator)
 		arrayId, exists := dMap.Data[s.ArrayIdKey]
-		if !exists {
+		if !exists || arrayId == nil {
 			arrayId = fmt.Sprintf("row-%d", i)
-			exists = true
 		}
 
-		id := arrayId.(string)
+		id := fmt.Sprint(arrayId)
 		errorMessages = s.ValidateObject(&d, &id)
 		if errorMessages.HasErrors() {
 			s.Logging.ERROR("has errors", errorMessages.GetStrings("en", "%data\n"))
@@ -364,6 +621,13 @@ func (s *Schematics) Operate(data interface{}) (interface{}, *errorHandler.Error
 
 func (s *Schematics) OperateOnObject(data map[string]interface{}) *map[string]interface{} {
 	data = *s.makeFlat(data)
+	s.applyDefaults(data)
+	if s.Coerce {
+		s.coerceFlat(data)
+	}
+	if s.StripUnknown {
+		s.removeKeys(data, s.unknownKeys(data))
+	}
 	for target, field := range s.Schema.Fields {
 		matchingKeys := utils.FindMatchingKeys(data, string(target))
 		for key, value := range matchingKeys {
@@ -0,0 +1,157 @@
+package v0
+
+import (
+	"github.com/ashbeelghouri/jsonschematics/errorHandler"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// unknownKeys returns the keys of the flat data which no target matches, only the keys inside a closed field
+// are returned unless the schematics are strict
+func (s *Schematics) unknownKeys(data map[string]interface{}) []string {
+	parents := make(map[string]bool)
+	for target := range s.Schema.Fields {
+		for _, parent := range parentTargets(string(target), s.Separator) {
+			parents[parent] = true
+		}
+	}
+	var known []*regexp.Regexp
+	var closed []*regexp.Regexp
+	for target, field := range s.Schema.Fields {
+		pattern := strings.TrimSuffix(utils.ConvertKeyToRegex(string(target)), "$")
+		inside := pattern + regexp.QuoteMeta(s.Separator)
+		if field.Closed {
+			closed = append(closed, regexp.MustCompile(inside))
+		}
+		// the content of a target is known when the schema does not describe it
+		if field.Closed || parents[string(target)] {
+			known = append(known, regexp.MustCompile(pattern+"$"))
+		} else {
+			known = append(known, regexp.MustCompile(pattern+"($|"+regexp.QuoteMeta(s.Separator)+")"))
+		}
+	}
+	// empty arrays and objects above the targets
+	for parent := range parents {
+		known = append(known, regexp.MustCompile(utils.ConvertKeyToRegex(parent)))
+	}
+
+	var unknown []string
+	for key := range data {
+		if matchesAny(known, key) || (!s.Strict && !matchesAny(closed, key)) {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// removeKeys deletes the keys from the flat data, an object which loses all of its keys is kept empty
+func (s *Schematics) removeKeys(data map[string]interface{}, keys []string) {
+	for _, key := range keys {
+		delete(data, key)
+		index := strings.LastIndex(key, s.Separator)
+		if index < 0 {
+			continue
+		}
+		parent := key[:index]
+		if !utils.HasNestedKeys(data, parent, s.Separator) {
+			data[parent] = map[string]interface{}{}
+		}
+	}
+}
+
+func parentTargets(target string, separator string) []string {
+	var parents []string
+	segments := strings.Split(target, separator)
+	for i := 1; i < len(segments); i++ {
+		parents = append(parents, strings.Join(segments[:i], separator))
+	}
+	return parents
+}
+
+func matchesAny(patterns []*regexp.Regexp, key string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schematics) unknownKeyError(key string, value interface{}, id *string) errorHandler.Error {
+	var err errorHandler.Error
+	err.Validator = "UnknownKey"
+	err.Value = value
+	err.ID = id
+	err.Suggestion = s.suggestTarget(key)
+	if err.Suggestion != "" {
+		err.AddMessage("en", "this key is not allowed, did you mean "+err.Suggestion+"?")
+	} else {
+		err.AddMessage("en", "this key is not allowed")
+	}
+	return err
+}
+
+// suggestTarget returns the nearest target key with the indexes of the key, or nothing when no target is close enough
+func (s *Schematics) suggestTarget(key string) string {
+	segments := strings.Split(key, s.Separator)
+	pattern := make([]string, len(segments))
+	for i, segment := range segments {
+		pattern[i] = segment
+		if utils.IsNumeric(segment) {
+			pattern[i] = "*"
+		}
+	}
+	generic := strings.Join(pattern, s.Separator)
+
+	best, bestDistance := "", -1
+	for _, target := range sortedTargets(s.Schema.Fields) {
+		distance := editDistance(generic, string(target))
+		if distance == 0 {
+			continue
+		}
+		// the last segment decides how many typos are expected
+		segments := strings.Split(string(target), s.Separator)
+		maxDistance := len(segments[len(segments)-1]) / 3
+		if maxDistance < 1 {
+			maxDistance = 1
+		}
+		if distance <= maxDistance && (bestDistance < 0 || distance < bestDistance) {
+			best, bestDistance = string(target), distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	scope := conditionScope{target: generic, key: key, separator: s.Separator}
+	return scope.resolve(best)
+}
+
+// editDistance counts the insertions, deletions, substitutions and transpositions between the strings
+func editDistance(a string, b string) int {
+	x, y := []rune(a), []rune(b)
+	d := make([][]int, len(x)+1)
+	for i := range d {
+		d[i] = make([]int, len(y)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(x)][len(y)]
+}
@@ -0,0 +1,295 @@
+package v0
+
+import (
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StructTag holds the rules of a struct field, e.g. `schematics:"required,MaxLengthAllowed=max:50,IsEmail"`,
+// attributes are separated by | and list values by ;, the rules after dive apply to the items of a slice
+const StructTag = "schematics"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type structGenerator struct {
+	validators *validators.Validators
+	separator  string
+	fields     map[TargetKey]Field
+	visiting   []reflect.Type
+}
+
+// LoadStruct generates the schema from the struct tags of v, the json tags name the target keys
+// and nested structs and slices are described with nested and * target keys
+func (s *Schematics) LoadStruct(v interface{}) error {
+	s.Configs()
+	s.Validators.BasicValidators()
+	if s.Separator == "" {
+		s.Separator = "."
+	}
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	if t == nil {
+		return fmt.Errorf("struct is required to generate the schema")
+	}
+	generator := structGenerator{
+		validators: &s.Validators,
+		separator:  s.Separator,
+		fields:     make(map[TargetKey]Field),
+	}
+	if err := generator.walk(t, ""); err != nil {
+		s.Logging.ERROR("Failed to generate the schema", err)
+		return err
+	}
+	s.loadSchema(Schema{Fields: generator.fields})
+	return nil
+}
+
+func (g *structGenerator) walk(t reflect.Type, prefix string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%s is not a struct", t)
+	}
+	for _, visiting := range g.visiting {
+		if visiting == t {
+			return fmt.Errorf("recursive type %s can not be described with target keys", t)
+		}
+	}
+	g.visiting = append(g.visiting, t)
+	defer func() {
+		g.visiting = g.visiting[:len(g.visiting)-1]
+	}()
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		name, skip := jsonFieldName(structField)
+		tag := structField.Tag.Get(StructTag)
+		if skip || tag == "-" {
+			continue
+		}
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if structField.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			if err := g.walk(fieldType, prefix); err != nil {
+				return err
+			}
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+		if err := g.describe(structField.Type, prefix+name, splitEscaped(tag, ',')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describe adds the field for the target when it has rules or holds a value, and walks into structs and slice items
+func (g *structGenerator) describe(t reflect.Type, target string, rules []string) error {
+	var itemRules []string
+	for i, rule := range rules {
+		if strings.TrimSpace(rule) == "dive" {
+			rules, itemRules = rules[:i], rules[i+1:]
+			break
+		}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// untagged values still get a target, so strict schematics know them
+	if len(rules) > 0 || isStructValue(t) {
+		if err := g.addField(target, t, rules); err != nil {
+			return fmt.Errorf("field %s: %w", target, err)
+		}
+	}
+
+	switch {
+	case t == timeType:
+	case t.Kind() == reflect.Struct:
+		return g.walk(t, target+g.separator)
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8:
+		return g.describe(t.Elem(), target+g.separator+"*", itemRules)
+	}
+	if len(itemRules) > 0 {
+		return fmt.Errorf("field %s: dive can only be used on slices", target)
+	}
+	return nil
+}
+
+func (g *structGenerator) addField(target string, t reflect.Type, rules []string) error {
+	segments := strings.Split(target, g.separator)
+	field := Field{
+		Name:       segments[len(segments)-1],
+		Type:       goTypeName(t),
+		Validators: make(map[string]Constant),
+	}
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		parts := splitEscaped(rule, '=')
+		name := strings.TrimSpace(unescapeTag(parts[0]))
+		if strings.EqualFold(name, "required") || utils.StringInStrings(strings.ToUpper(name), utils.ExcludedValidators) {
+			field.IsRequired = true
+			continue
+		}
+		if _, exists := g.validators.ValidationFns[name]; !exists {
+			return fmt.Errorf("validator %s is not registered", name)
+		}
+		var constant Constant
+		if len(parts) > 1 {
+			attributes, err := g.parseAttributes(name, strings.Join(parts[1:], "="))
+			if err != nil {
+				return err
+			}
+			constant.Attributes = attributes
+		}
+		field.Validators[name] = constant
+	}
+	g.fields[TargetKey(target)] = field
+	return nil
+}
+
+// parseAttributes reads name:value pairs, the values are converted to the attribute types of the descriptor
+func (g *structGenerator) parseAttributes(validator string, raw string) (map[string]interface{}, error) {
+	descriptor, described := g.validators.GetDescriptor(validator)
+	attributes := make(map[string]interface{})
+	for _, pair := range splitEscaped(raw, '|') {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("validator %s: attribute %s should be name:value", validator, unescapeTag(pair))
+		}
+		name := strings.TrimSpace(unescapeTag(parts[0]))
+		attrType := ""
+		if described {
+			if attribute := descriptor.GetAttribute(name); attribute != nil {
+				attrType = attribute.Type
+			}
+		}
+		value, err := parseTagValue(parts[1], attrType)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: attribute %s: %w", validator, name, err)
+		}
+		attributes[name] = value
+	}
+	return attributes, nil
+}
+
+func parseTagValue(raw string, attrType string) (interface{}, error) {
+	switch attrType {
+	case utils.TypeArray:
+		var values []interface{}
+		for _, item := range splitEscaped(raw, ';') {
+			values = append(values, unescapeTag(item))
+		}
+		return values, nil
+	case utils.TypeNumber, utils.TypeInteger:
+		return strconv.ParseFloat(unescapeTag(raw), 64)
+	case utils.TypeBoolean:
+		return strconv.ParseBool(unescapeTag(raw))
+	case utils.TypeString, utils.TypeDate:
+		return unescapeTag(raw), nil
+	}
+	if items := splitEscaped(raw, ';'); len(items) > 1 {
+		return parseTagValue(raw, utils.TypeArray)
+	}
+	value := unescapeTag(raw)
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, nil
+	}
+	if boolean, err := strconv.ParseBool(value); err == nil {
+		return boolean, nil
+	}
+	return value, nil
+}
+
+func jsonFieldName(structField reflect.StructField) (string, bool) {
+	tag := structField.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+// isStructValue checks if the type is a value of its own, structs and slices are described by their fields and items
+func isStructValue(t reflect.Type) bool {
+	switch {
+	case t == timeType:
+		return true
+	case t.Kind() == reflect.Struct:
+		return false
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return t.Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
+
+func goTypeName(t reflect.Type) string {
+	if t == timeType {
+		return utils.TypeDate
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return utils.TypeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return utils.TypeInteger
+	case reflect.Float32, reflect.Float64:
+		return utils.TypeNumber
+	case reflect.Bool:
+		return utils.TypeBoolean
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return utils.TypeString
+		}
+		return utils.TypeArray
+	case reflect.Struct, reflect.Map:
+		return utils.TypeObject
+	}
+	return ""
+}
+
+// splitEscaped splits on the separator unless it is escaped with a backslash, escapes are kept for the next split
+func splitEscaped(s string, separator byte) []string {
+	if s == "" {
+		return nil
+	}
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case separator:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unescapeTag(s string) string {
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(",|;=\\", s[i+1]) >= 0 {
+			i++
+		}
+		builder.WriteByte(s[i])
+	}
+	return builder.String()
+}
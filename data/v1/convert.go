@@ -0,0 +1,75 @@
+package v1
+
+import (
+	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"sort"
+)
+
+// FromV0 converts the base schema into the v1 format, the fields are sorted by the target key
+func FromV0(schema *v0.Schema) *Schema {
+	converted := Schema{Version: schema.Version}
+	var targets []string
+	for target := range schema.Fields {
+		targets = append(targets, string(target))
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		field := schema.Fields[v0.TargetKey(target)]
+		converted.Fields = append(converted.Fields, Field{
+			DependsOn:             field.DependsOn,
+			DisplayName:           field.DisplayName,
+			Name:                  field.Name,
+			TargetKey:             target,
+			Type:                  field.Type,
+			IsRequired:            field.IsRequired,
+			Nullable:              field.Nullable,
+			AllowEmpty:            field.AllowEmpty,
+			Closed:                field.Closed,
+			Default:               field.Default,
+			When:                  field.When,
+			Description:           field.Description,
+			Validators:            fromV0Constants(field.Validators),
+			ValidatorOrder:        field.ValidatorOrder,
+			Operators:             fromV0Constants(field.Operators),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
+		})
+	}
+	return &converted
+}
+
+func (s *Schema) ToV0() *v0.Schema {
+	return transformSchema(*s)
+}
+
+// Export writes the schema as pretty printed json or yaml
+func (s *Schema) Export(format string) ([]byte, error) {
+	return utils.MarshalSchema(s, format)
+}
+
+// MarshalJSON writes the validators in the order they are declared
+func (f Field) MarshalJSON() ([]byte, error) {
+	type plain Field
+	return utils.MarshalJSON(struct {
+		plain
+		Validators utils.OrderedObject `json:"validators,omitempty"`
+	}{plain(f), utils.NewOrderedObject(f.Validators, f.ValidatorOrder)})
+}
+
+func fromV0Constants(constants map[string]v0.Constant) map[string]Component {
+	if len(constants) == 0 {
+		return nil
+	}
+	components := make(map[string]Component, len(constants))
+	for name, c := range constants {
+		components[name] = Component{
+			Attributes: c.Attributes,
+			Error:      c.Error,
+			L10n:       c.L10n,
+			When:       c.When,
+			Priority:   c.Priority,
+		}
+	}
+	return components
+}
@@ -2,12 +2,16 @@ package v1
 
 import (
 	"encoding/json"
+	"fmt"
 	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
 	"github.com/ashbeelghouri/jsonschematics/operators"
 	"github.com/ashbeelghouri/jsonschematics/utils"
 	"github.com/ashbeelghouri/jsonschematics/validators"
+	"io"
+	"io/fs"
 	"log"
 	"os"
+	"strconv"
 )
 
 var Logs utils.Logger
@@ -28,23 +32,32 @@ type Schema struct {
 }
 
 type Field struct {
-	DependsOn             []string               `json:"depends_on"`
-	DisplayName           string                 `json:"display_name"`
-	Name                  string                 `json:"name"`
-	TargetKey             string                 `json:"target_key"`
-	Type                  string                 `json:"type"`
-	IsRequired            bool                   `json:"required"`
-	Description           string                 `json:"description"`
-	Validators            map[string]Component   `json:"validators"`
-	Operators             map[string]Component   `json:"operators"`
-	L10n                  map[string]interface{} `json:"l10n"`
-	AdditionalInformation map[string]interface{} `json:"additional_information"`
+	DependsOn   []string             `json:"depends_on,omitempty"`
+	DisplayName string               `json:"display_name,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	TargetKey   string               `json:"target_key"`
+	Type        string               `json:"type,omitempty"`
+	IsRequired  bool                 `json:"required,omitempty"`
+	Nullable    *bool                `json:"nullable,omitempty"`
+	AllowEmpty  *bool                `json:"allow_empty,omitempty"`
+	Closed      bool                 `json:"closed,omitempty"`
+	Default     interface{}          `json:"default,omitempty"`
+	When        *v0.Condition        `json:"when,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Validators  map[string]Component `json:"validators,omitempty"`
+	// ValidatorOrder is the order in which the validators are declared, it is read from the schema file
+	ValidatorOrder        []string               `json:"-"`
+	Operators             map[string]Component   `json:"operators,omitempty"`
+	L10n                  map[string]interface{} `json:"l10n,omitempty"`
+	AdditionalInformation map[string]interface{} `json:"additional_information,omitempty"`
 }
 
 type Component struct {
-	Attributes map[string]interface{} `json:"attributes"`
-	Error      string                 `json:"error"`
-	L10n       map[string]interface{} `json:"l10n"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	L10n       map[string]interface{} `json:"l10n,omitempty"`
+	When       *v0.Condition          `json:"when,omitempty"`
+	Priority   int                    `json:"priority,omitempty"`
 }
 
 func (s *Schematics) Configs() {
@@ -62,26 +75,97 @@ func (s *Schematics) Configs() {
 }
 
 func LoadJsonSchemaFile(path string) (*v0.Schematics, error) {
-	var s *Schematics
-	s.Configs()
+	return loadSchemaFile(path, utils.FormatJson)
+}
+
+func LoadYamlSchemaFile(path string) (*v0.Schematics, error) {
+	return loadSchemaFile(path, utils.FormatYaml)
+}
+
+func LoadSchemaFile(path string) (*v0.Schematics, error) {
+	return loadSchemaFile(path, "")
+}
+
+func loadSchemaFile(path string, format string) (*v0.Schematics, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		Logs.ERROR("Failed to load schema file", err)
 		return nil, err
 	}
-	var schema Schema
-	err = json.Unmarshal(content, &schema)
+	return loadSchema(path, content, format)
+}
+
+// LoadSchemaFS loads the schema file from the file system, for example an embed.FS
+func LoadSchemaFS(fsys fs.FS, name string) (*v0.Schematics, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		Logs.ERROR("Failed to load schema file", err)
+		return nil, err
+	}
+	return loadSchema(name, content, "")
+}
+
+// LoadSchemaReader reads a json or yaml schema, the format is detected from the content when it is empty
+func LoadSchemaReader(r io.Reader, format string) (*v0.Schematics, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		Logs.ERROR("Failed to read schema", err)
+		return nil, err
+	}
+	return loadSchema("", content, format)
+}
+
+// LoadSchemasFS loads every file of the file system matching the glob pattern, keyed by the path of the file
+func LoadSchemasFS(fsys fs.FS, pattern string) (map[string]*v0.Schematics, error) {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*v0.Schematics)
+	for _, name := range names {
+		schema, err := LoadSchemaFS(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+func loadSchema(path string, content []byte, format string) (*v0.Schematics, error) {
+	var s Schematics
+	s.Configs()
+	if format == "" {
+		format = utils.DetectFormat(path, content)
+	}
+	schema, err := ParseSchema(content, format)
 	if err != nil {
 		Logs.ERROR("Failed to unmarshall schema file", err)
 		return nil, err
 	}
-	s.Schema = schema
+	s.Schema = *schema
 
-	return transformSchematics(*s), nil
+	return transformSchematics(s), nil
+}
+
+// ParseSchema unmarshalls a json or yaml schema, the validators keep the order of the document
+func ParseSchema(content []byte, format string) (*Schema, error) {
+	var schema Schema
+	if err := utils.UnmarshalSchema(content, format, &schema); err != nil {
+		return nil, err
+	}
+	order, err := utils.ReadKeyOrder(content, format)
+	if err != nil {
+		return nil, err
+	}
+	for i := range schema.Fields {
+		schema.Fields[i].ValidatorOrder = order.Keys("fields", strconv.Itoa(i), "validators")
+	}
+	return &schema, nil
 }
 
 func LoadMap(schemaMap interface{}) (*v0.Schematics, error) {
-	var s *Schematics
+	var s Schematics
 	s.Configs()
 	jsonBytes, err := json.Marshal(schemaMap)
 	if err != nil {
@@ -95,7 +179,7 @@ func LoadMap(schemaMap interface{}) (*v0.Schematics, error) {
 		return nil, err
 	}
 	s.Schema = schema
-	return transformSchematics(*s), nil
+	return transformSchematics(s), nil
 }
 
 func transformSchematics(s Schematics) *v0.Schematics {
@@ -104,6 +188,9 @@ func transformSchematics(s Schematics) *v0.Schematics {
 	baseSchematics.Logging = s.Logging
 	baseSchematics.ArrayIdKey = s.ArrayIdKey
 	baseSchematics.Separator = s.Separator
+	if baseSchematics.Separator == "" {
+		baseSchematics.Separator = "."
+	}
 	baseSchematics.Validators = s.Validators
 	baseSchematics.Operators = s.Operators
 	baseSchematics.Validators.BasicValidators()
@@ -120,11 +207,18 @@ func transformSchema(schema Schema) *v0.Schema {
 	for _, field := range schema.Fields {
 		baseSchema.Fields[v0.TargetKey(field.TargetKey)] = v0.Field{
 			DependsOn:             field.DependsOn,
+			DisplayName:           field.DisplayName,
 			Name:                  field.Name,
-			Type:                  field.Name,
+			Type:                  field.Type,
 			IsRequired:            field.IsRequired,
+			Nullable:              field.Nullable,
+			AllowEmpty:            field.AllowEmpty,
+			Closed:                field.Closed,
+			Default:               field.Default,
+			When:                  field.When,
 			Description:           field.Description,
 			Validators:            transformComponents(field.Validators),
+			ValidatorOrder:        field.ValidatorOrder,
 			Operators:             transformComponents(field.Operators),
 			L10n:                  field.L10n,
 			AdditionalInformation: field.AdditionalInformation,
@@ -141,6 +235,8 @@ func transformComponents(comp map[string]Component) map[string]v0.Constant {
 			Attributes: c.Attributes,
 			Error:      c.Error,
 			L10n:       c.L10n,
+			When:       c.When,
+			Priority:   c.Priority,
 		}
 	}
 	return con
@@ -0,0 +1,148 @@
+package v2
+
+import (
+	"fmt"
+	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
+	v1 "github.com/ashbeelghouri/jsonschematics/data/v1"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"os"
+)
+
+// FromV0 converts the base schema into the v2 format, the fields are sorted by the target key,
+// the validators keep the order of the schema file and the operators are sorted by their name
+func FromV0(schema *v0.Schema) *Schema {
+	return FromV1(v1.FromV0(schema))
+}
+
+func (s *Schema) ToV0() *v0.Schema {
+	return transformSchema(*s)
+}
+
+func FromV1(schema *v1.Schema) *Schema {
+	converted := Schema{Version: schema.Version}
+	for _, field := range schema.Fields {
+		converted.Fields = append(converted.Fields, Field{
+			DependsOn:             field.DependsOn,
+			DisplayName:           field.DisplayName,
+			Name:                  field.Name,
+			TargetKey:             field.TargetKey,
+			Type:                  field.Type,
+			IsRequired:            field.IsRequired,
+			Nullable:              field.Nullable,
+			AllowEmpty:            field.AllowEmpty,
+			Closed:                field.Closed,
+			Default:               field.Default,
+			When:                  field.When,
+			Description:           field.Description,
+			Validators:            fromV1Components(field.Validators, field.ValidatorOrder),
+			Operators:             fromV1Components(field.Operators, nil),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
+		})
+	}
+	return &converted
+}
+
+// ToV1 converts the schema into the v1 format, when a validator or an operator
+// is listed more than once the last one is kept
+func (s *Schema) ToV1() *v1.Schema {
+	converted := v1.Schema{Version: s.Version}
+	for _, field := range s.Fields {
+		converted.Fields = append(converted.Fields, v1.Field{
+			DependsOn:             field.DependsOn,
+			DisplayName:           field.DisplayName,
+			Name:                  field.Name,
+			TargetKey:             field.TargetKey,
+			Type:                  field.Type,
+			IsRequired:            field.IsRequired,
+			Nullable:              field.Nullable,
+			AllowEmpty:            field.AllowEmpty,
+			Closed:                field.Closed,
+			Default:               field.Default,
+			When:                  field.When,
+			Description:           field.Description,
+			Validators:            toV1Components(field.Validators),
+			ValidatorOrder:        componentNames(field.Validators),
+			Operators:             toV1Components(field.Operators),
+			L10n:                  field.L10n,
+			AdditionalInformation: field.AdditionalInformation,
+		})
+	}
+	return &converted
+}
+
+// Export writes the schema as pretty printed json or yaml
+func (s *Schema) Export(format string) ([]byte, error) {
+	return utils.MarshalSchema(s, format)
+}
+
+// UpgradeFile reads a v0, v1 or v2 schema file and converts it into the v2 format,
+// the includes and parent schemas of v0 files are resolved
+func UpgradeFile(path string) (*Schema, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	format := utils.DetectFormat(path, content)
+	version, err := utils.DetectSchemaVersion(content, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	switch version {
+	case utils.SchemaV0:
+		var schematics v0.Schematics
+		if err := schematics.LoadSchemaFile(path); err != nil {
+			return nil, err
+		}
+		return FromV0(&schematics.Schema), nil
+	case utils.SchemaV1:
+		schema, err := v1.ParseSchema(content, format)
+		if err != nil {
+			return nil, err
+		}
+		return FromV1(schema), nil
+	}
+	var schema Schema
+	if err := utils.UnmarshalSchema(content, format, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// fromV1Components lists the components in the order, the components missing from the order are sorted by their name
+func fromV1Components(components map[string]v1.Component, order []string) []Component {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	var converted []Component
+	for _, name := range utils.OrderKeys(order, names) {
+		c := components[name]
+		converted = append(converted, Component{
+			Name:       name,
+			Attributes: c.Attributes,
+			Error:      c.Error,
+			L10n:       c.L10n,
+			When:       c.When,
+			Priority:   c.Priority,
+		})
+	}
+	return converted
+}
+
+func toV1Components(components []Component) map[string]v1.Component {
+	if len(components) == 0 {
+		return nil
+	}
+	converted := make(map[string]v1.Component, len(components))
+	for _, c := range components {
+		converted[c.Name] = v1.Component{
+			Attributes: c.Attributes,
+			Error:      c.Error,
+			L10n:       c.L10n,
+			When:       c.When,
+			Priority:   c.Priority,
+		}
+	}
+	return converted
+}
@@ -3,10 +3,13 @@ package v2
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
 	"github.com/ashbeelghouri/jsonschematics/operators"
 	"github.com/ashbeelghouri/jsonschematics/utils"
 	"github.com/ashbeelghouri/jsonschematics/validators"
+	"io"
+	"io/fs"
 	"os"
 )
 
@@ -26,34 +29,95 @@ type Schema struct {
 }
 
 type Field struct {
-	DependsOn             []string               `json:"depends_on"`
-	DisplayName           string                 `json:"display_name"`
-	Name                  string                 `json:"name"`
+	DependsOn             []string               `json:"depends_on,omitempty"`
+	DisplayName           string                 `json:"display_name,omitempty"`
+	Name                  string                 `json:"name,omitempty"`
 	TargetKey             string                 `json:"target_key"`
-	Type                  string                 `json:"type"`
-	IsRequired            bool                   `json:"required"`
-	Description           string                 `json:"description"`
-	Validators            []Component            `json:"validators"`
-	Operators             []Component            `json:"operators"`
-	L10n                  map[string]interface{} `json:"l10n"`
-	AdditionalInformation map[string]interface{} `json:"additional_information"`
+	Type                  string                 `json:"type,omitempty"`
+	IsRequired            bool                   `json:"required,omitempty"`
+	Nullable              *bool                  `json:"nullable,omitempty"`
+	AllowEmpty            *bool                  `json:"allow_empty,omitempty"`
+	Closed                bool                   `json:"closed,omitempty"`
+	Default               interface{}            `json:"default,omitempty"`
+	When                  *v0.Condition          `json:"when,omitempty"`
+	Description           string                 `json:"description,omitempty"`
+	Validators            []Component            `json:"validators,omitempty"`
+	Operators             []Component            `json:"operators,omitempty"`
+	L10n                  map[string]interface{} `json:"l10n,omitempty"`
+	AdditionalInformation map[string]interface{} `json:"additional_information,omitempty"`
 }
 
 type Component struct {
 	Name       string                 `json:"name"`
-	Attributes map[string]interface{} `json:"attributes"`
-	Error      string                 `json:"error"`
-	L10n       map[string]interface{} `json:"l10n"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	L10n       map[string]interface{} `json:"l10n,omitempty"`
+	When       *v0.Condition          `json:"when,omitempty"`
+	Priority   int                    `json:"priority,omitempty"`
 }
 
 func LoadJsonSchemaFile(path string) (*v0.Schematics, error) {
-	var s Schematics
+	return loadSchemaFile(path, utils.FormatJson)
+}
+
+func LoadYamlSchemaFile(path string) (*v0.Schematics, error) {
+	return loadSchemaFile(path, utils.FormatYaml)
+}
+
+func LoadSchemaFile(path string) (*v0.Schematics, error) {
+	return loadSchemaFile(path, "")
+}
+
+func loadSchemaFile(path string, format string) (*v0.Schematics, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return loadSchema(path, content, format)
+}
+
+// LoadSchemaFS loads the schema file from the file system, for example an embed.FS
+func LoadSchemaFS(fsys fs.FS, name string) (*v0.Schematics, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return loadSchema(name, content, "")
+}
+
+// LoadSchemaReader reads a json or yaml schema, the format is detected from the content when it is empty
+func LoadSchemaReader(r io.Reader, format string) (*v0.Schematics, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return loadSchema("", content, format)
+}
+
+// LoadSchemasFS loads every file of the file system matching the glob pattern, keyed by the path of the file
+func LoadSchemasFS(fsys fs.FS, pattern string) (map[string]*v0.Schematics, error) {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*v0.Schematics)
+	for _, name := range names {
+		schema, err := LoadSchemaFS(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		schemas[name] = schema
+	}
+	return schemas, nil
+}
+
+func loadSchema(path string, content []byte, format string) (*v0.Schematics, error) {
+	var s Schematics
+	if format == "" {
+		format = utils.DetectFormat(path, content)
+	}
 	var schema Schema
-	err = json.Unmarshal(content, &schema)
+	err := utils.UnmarshalSchema(content, format, &schema)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +156,9 @@ func transformSchematics(s Schematics) *v0.Schematics {
 
 	baseSchematics.ArrayIdKey = s.ArrayIdKey
 	baseSchematics.Separator = s.Separator
+	if baseSchematics.Separator == "" {
+		baseSchematics.Separator = "."
+	}
 	baseSchematics.Validators.BasicValidators()
 	baseSchematics.Operators.LoadBasicOperations()
 	baseSchematics.Schema = *transformSchema(s.Schema)
@@ -106,11 +173,18 @@ func transformSchema(schema Schema) *v0.Schema {
 	for _, field := range schema.Fields {
 		baseSchema.Fields[v0.TargetKey(field.TargetKey)] = v0.Field{
 			DependsOn:             field.DependsOn,
+			DisplayName:           field.DisplayName,
 			Name:                  field.Name,
-			Type:                  field.Name,
+			Type:                  field.Type,
 			IsRequired:            field.IsRequired,
+			Nullable:              field.Nullable,
+			AllowEmpty:            field.AllowEmpty,
+			Closed:                field.Closed,
+			Default:               field.Default,
+			When:                  field.When,
 			Description:           field.Description,
 			Validators:            transformComponents(field.Validators),
+			ValidatorOrder:        componentNames(field.Validators),
 			Operators:             transformComponents(field.Operators),
 			L10n:                  field.L10n,
 			AdditionalInformation: field.AdditionalInformation,
@@ -119,6 +193,14 @@ func transformSchema(schema Schema) *v0.Schema {
 	return &baseSchema
 }
 
+func componentNames(components []Component) []string {
+	var names []string
+	for _, c := range components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
 func transformComponents(comp []Component) map[string]v0.Constant {
 	con := make(map[string]v0.Constant)
 	for _, c := range comp {
@@ -126,6 +208,8 @@ func transformComponents(comp []Component) map[string]v0.Constant {
 			Attributes: c.Attributes,
 			Error:      c.Error,
 			L10n:       c.L10n,
+			When:       c.When,
+			Priority:   c.Priority,
 		}
 	}
 	return con
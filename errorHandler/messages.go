@@ -18,10 +18,18 @@ type Error struct {
 	Value      interface{}
 	ID         interface{}
 	Data       map[string]interface{}
+	// RelatedTarget and RelatedValue are the other field of the validators comparing two fields
+	RelatedTarget string
+	RelatedValue  interface{}
+	// Suggestion is the nearest target key of an unknown key
+	Suggestion string
 }
 
+// Errors keeps one error of every target in Messages, like before an error added to a target replaces the previous one.
+// Failures has all the errors of the last AddErrors of a target in the order they are added and Messages has the first of them
 type Errors struct {
 	Messages map[Target]Error
+	Failures map[Target][]Error
 }
 
 func (e *Error) AddMessage(local string, message string) {
@@ -46,15 +54,58 @@ func (e *Error) updateData(target string) Target {
 	e.Data["value"] = e.Value
 	e.Data["value"] = e.Value
 	e.Data["id"] = e.ID
+	if e.RelatedTarget != "" {
+		e.Data["related_target"] = e.RelatedTarget
+		e.Data["related_value"] = e.RelatedValue
+	}
+	if e.Suggestion != "" {
+		e.Data["suggestion"] = e.Suggestion
+	}
 	return Target(t)
 }
 
 func (em *Errors) AddError(target string, err Error) {
+	t := err.updateData(target)
+	em.add(t, []Error{err})
+}
+
+// AddErrors adds all the errors of a single target, they replace the errors which were added to the target before
+func (em *Errors) AddErrors(target string, errs []Error) {
+	if len(errs) == 0 {
+		return
+	}
+	added := make([]Error, len(errs))
+	var t Target
+	for i, err := range errs {
+		t = err.updateData(target)
+		added[i] = err
+	}
+	em.add(t, added)
+}
+
+func (em *Errors) add(t Target, errs []Error) {
 	if em.Messages == nil {
 		em.Messages = make(map[Target]Error)
 	}
-	t := err.updateData(target)
-	em.Messages[t] = err
+	if em.Failures == nil {
+		em.Failures = make(map[Target][]Error)
+	}
+	em.Messages[t] = errs[0]
+	em.Failures[t] = errs
+}
+
+// ErrorsOf returns all the errors of the target, the errors only set in Messages are returned as well
+func (em *Errors) ErrorsOf(target Target) []Error {
+	if em == nil {
+		return nil
+	}
+	if errs := em.Failures[target]; len(errs) > 0 {
+		return errs
+	}
+	if err, exists := em.Messages[target]; exists {
+		return []Error{err}
+	}
+	return nil
 }
 
 func (em *Errors) HasErrors() bool {
@@ -77,21 +128,23 @@ func (em *Errors) GetStrings(locale Locale, format string) *[]string {
 		format = "validation error %message for %target with validation on %validator, provided: %value: {%data}"
 	}
 
-	for target, msg := range em.Messages {
+	for target := range em.Messages {
 		log.Println(target)
-		message, ok := msg.Message[locale]
-		if !ok {
-			continue
-		}
-		value := fmt.Sprint(msg.Value)
-		var id *string
-		if msg.ID != nil {
-			msgID := fmt.Sprint(msg.ID)
-			id = &msgID
-		} else {
-			id = nil
+		for _, msg := range em.ErrorsOf(target) {
+			message, ok := msg.Message[locale]
+			if !ok {
+				continue
+			}
+			value := fmt.Sprint(msg.Value)
+			var id *string
+			if msg.ID != nil {
+				msgID := fmt.Sprint(msg.ID)
+				id = &msgID
+			} else {
+				id = nil
+			}
+			errs = append(errs, utils.FormatError(id, message, string(target), msg.Validator, value, format, &msg.Data))
 		}
-		errs = append(errs, utils.FormatError(id, message, string(target), msg.Validator, value, format, &msg.Data))
 	}
 	return &errs
 }
@@ -105,21 +158,23 @@ func (em *Errors) GetErrors(locale Locale, format string) *[]error {
 		format = "validation error %message for %target with validation on %validator, provided: %value"
 	}
 
-	for target, msg := range em.Messages {
+	for target := range em.Messages {
 		log.Println(target)
-		message, ok := msg.Message[locale]
-		if !ok {
-			continue
-		}
-		value := fmt.Sprint(msg.Value)
-		var id *string
-		if msg.ID != nil {
-			msgID := fmt.Sprint(msg.ID)
-			id = &msgID
-		} else {
-			id = nil
+		for _, msg := range em.ErrorsOf(target) {
+			message, ok := msg.Message[locale]
+			if !ok {
+				continue
+			}
+			value := fmt.Sprint(msg.Value)
+			var id *string
+			if msg.ID != nil {
+				msgID := fmt.Sprint(msg.ID)
+				id = &msgID
+			} else {
+				id = nil
+			}
+			errs = append(errs, errors.New(utils.FormatError(id, message, string(target), msg.Validator, value, format, &msg.Data)))
 		}
-		errs = append(errs, errors.New(utils.FormatError(id, message, string(target), msg.Validator, value, format, &msg.Data)))
 	}
 	return &errs
 }
@@ -142,10 +197,7 @@ func (em *Errors) MergeErrors(em2 *Errors) {
 	if !em2.HasErrors() {
 		return
 	}
-	if em.Messages == nil {
-		em.Messages = make(map[Target]Error)
-	}
-	for target, err := range em2.Messages {
-		em.Messages[target] = err
+	for target := range em2.Messages {
+		em.add(target, append([]Error{}, em2.ErrorsOf(target)...))
 	}
 }
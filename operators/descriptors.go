@@ -0,0 +1,56 @@
+package operators
+
+import "github.com/ashbeelghouri/jsonschematics/utils"
+
+var BasicDescriptors = []utils.Descriptor{
+	// string operations
+	{
+		Name:        "Capitalize",
+		Description: "uppercase the first letter and lowercase the rest of the string",
+		AppliesTo:   []string{utils.TypeString},
+	},
+	{
+		Name:        "UpperCase",
+		Description: "uppercase the whole string",
+		AppliesTo:   []string{utils.TypeString},
+	},
+	{
+		Name:        "LowerCase",
+		Description: "lowercase the whole string",
+		AppliesTo:   []string{utils.TypeString},
+	},
+
+	// number operations
+	{
+		Name:        "Add",
+		Description: "add add_with to the number",
+		Attributes: []utils.Attribute{
+			{Name: "add_with", Type: utils.TypeNumber, Required: true},
+		},
+		AppliesTo: []string{utils.TypeNumber},
+	},
+	{
+		Name:        "Subtract",
+		Description: "subtract subtract_with from the number",
+		Attributes: []utils.Attribute{
+			{Name: "subtract_with", Type: utils.TypeNumber, Required: true},
+		},
+		AppliesTo: []string{utils.TypeNumber},
+	},
+	{
+		Name:        "Multiply",
+		Description: "multiply the number with multiply_with",
+		Attributes: []utils.Attribute{
+			{Name: "multiply_with", Type: utils.TypeNumber, Required: true},
+		},
+		AppliesTo: []string{utils.TypeNumber},
+	},
+	{
+		Name:        "Divide",
+		Description: "divide the number by divide_with",
+		Attributes: []utils.Attribute{
+			{Name: "divide_with", Type: utils.TypeNumber, Required: true},
+		},
+		AppliesTo: []string{utils.TypeNumber},
+	},
+}
@@ -1,14 +1,22 @@
 package operators
 
-import "github.com/ashbeelghouri/jsonschematics/utils"
+import (
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"sort"
+)
 
 type Operators struct {
 	OpFunctions map[string]Op
+	Descriptors map[string]utils.Descriptor
 	Logger      utils.Logger
+	basicLoaded bool
 }
 
 type Op func(interface{}, map[string]interface{}) *interface{}
 
+// RequiredAttributes lists the attributes the basic operations can not work without, it is built from BasicDescriptors
+var RequiredAttributes = utils.RequiredAttributes(BasicDescriptors)
+
 func (op *Operators) RegisterOperation(name string, fn Op) {
 	op.Logger.DEBUG("registering operation:", name)
 	if op.OpFunctions == nil {
@@ -17,7 +25,35 @@ func (op *Operators) RegisterOperation(name string, fn Op) {
 	op.OpFunctions[name] = fn
 }
 
+func (op *Operators) DescribeOperation(descriptor utils.Descriptor) {
+	if op.Descriptors == nil {
+		op.Descriptors = make(map[string]utils.Descriptor)
+	}
+	op.Descriptors[descriptor.Name] = descriptor
+}
+
+func (op *Operators) GetDescriptor(name string) (utils.Descriptor, bool) {
+	descriptor, exists := op.Descriptors[name]
+	return descriptor, exists
+}
+
+func (op *Operators) GetDescriptors() []utils.Descriptor {
+	var descriptors []utils.Descriptor
+	for _, descriptor := range op.Descriptors {
+		descriptors = append(descriptors, descriptor)
+	}
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].Name < descriptors[j].Name
+	})
+	return descriptors
+}
+
+// LoadBasicOperations registers the built-in operations once
 func (op *Operators) LoadBasicOperations() {
+	if op.basicLoaded {
+		return
+	}
+	op.basicLoaded = true
 	op.Logger.DEBUG("loading basic operations")
 	op.RegisterOperation("Capitalize", Capitalize)
 	op.RegisterOperation("UpperCase", UpperCase)
@@ -29,5 +65,9 @@ func (op *Operators) LoadBasicOperations() {
 	op.RegisterOperation("Multiply", Multiply)
 	op.RegisterOperation("Divide", Divide)
 
+	for _, descriptor := range BasicDescriptors {
+		op.DescribeOperation(descriptor)
+	}
+
 	op.Logger.DEBUG("basic operations loaded")
 }
@@ -0,0 +1,313 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
+	v1 "github.com/ashbeelghouri/jsonschematics/data/v1"
+	v2 "github.com/ashbeelghouri/jsonschematics/data/v2"
+	"github.com/ashbeelghouri/jsonschematics/operators"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"github.com/ashbeelghouri/jsonschematics/validators"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const Latest = "latest"
+
+// Registry holds schematics keyed by name and version, every entry shares the validators and the operators
+// of the registry so custom functions should be registered before the schemas are added,
+// lookups are safe to use from concurrent http handlers
+type Registry struct {
+	Validators validators.Validators
+	Operators  operators.Operators
+	Separator  string
+	Locale     string
+	Logging    utils.Logger
+	mutex      sync.RWMutex
+	entries    map[string][]*Entry
+}
+
+type Entry struct {
+	Name       string
+	Version    string
+	Path       string
+	Schematics *v0.Schematics
+	semver     [3]int
+}
+
+func (r *Registry) init() {
+	r.Validators.Logger = r.Logging
+	r.Operators.Logger = r.Logging
+	r.Validators.BasicValidators()
+	r.Operators.LoadBasicOperations()
+	if r.entries == nil {
+		r.entries = make(map[string][]*Entry)
+	}
+}
+
+// Register adds the schematics under the version of its schema, an entry with the same name and version is replaced
+func (r *Registry) Register(name string, schematics *v0.Schematics) error {
+	_, _, err := r.register(name, "", schematics)
+	return err
+}
+
+// register returns the new entry and the entry with the same name and version it replaced
+func (r *Registry) register(name string, path string, schematics *v0.Schematics) (*Entry, *Entry, error) {
+	version := schematics.Schema.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+	parsed, err := utils.ParseSemver(version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.init()
+	schematics.Validators = r.Validators
+	schematics.Operators = r.Operators
+	entry := &Entry{
+		Name:       name,
+		Version:    version,
+		Path:       path,
+		Schematics: schematics,
+		semver:     parsed,
+	}
+	return entry, r.insert(entry), nil
+}
+
+// restore puts back an entry which was replaced or removed
+func (r *Registry) restore(entry *Entry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.init()
+	r.insert(entry)
+}
+
+func (r *Registry) insert(entry *Entry) *Entry {
+	entries := r.entries[entry.Name]
+	for i, existing := range entries {
+		if existing.semver == entry.semver {
+			updated := append([]*Entry{}, entries...)
+			updated[i] = entry
+			r.entries[entry.Name] = updated
+			return existing
+		}
+	}
+	entries = append(append([]*Entry{}, entries...), entry)
+	sort.Slice(entries, func(i, j int) bool {
+		return utils.CompareSemver(entries[i].semver, entries[j].semver) < 0
+	})
+	r.entries[entry.Name] = entries
+	return nil
+}
+
+func (r *Registry) Remove(name string, version string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var kept []*Entry
+	for _, entry := range r.entries[name] {
+		if entry.Version != version {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) == 0 {
+		delete(r.entries, name)
+		return
+	}
+	r.entries[name] = kept
+}
+
+// Get returns the highest version of the schema matching the constraint,
+// the constraint can be empty or "latest", an exact version or a semver range like "^1.2" or ">=1.0.0 <2.0.0"
+func (r *Registry) Get(name string, constraint string) (*v0.Schematics, error) {
+	entry, err := r.GetEntry(name, constraint)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Schematics, nil
+}
+
+func (r *Registry) GetEntry(name string, constraint string) (*Entry, error) {
+	r.mutex.RLock()
+	entries := r.entries[name]
+	r.mutex.RUnlock()
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("schema %s is not registered", name)
+	}
+	if constraint == "" || constraint == Latest {
+		return entries[len(entries)-1], nil
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		matched, err := utils.SemverMatches(entries[i].Version, constraint)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			return entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("schema %s has no version matching %s", name, constraint)
+}
+
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var names []string
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Versions lists the registered versions of the schema from the lowest to the highest
+func (r *Registry) Versions(name string) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var versions []string
+	for _, entry := range r.entries[name] {
+		versions = append(versions, entry.Version)
+	}
+	return versions
+}
+
+// LoadDir registers every json and yaml schema file of the directory tree, the schema version (v0, v1 or v2)
+// is detected from the content and the name is the path relative to the directory without the extension,
+// a version suffix in the file name is dropped, so users@1.2.0.json is registered as users
+func (r *Registry) LoadDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isSchemaFile(path) {
+			return nil
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		return r.LoadFile(SchemaName(relative), path)
+	})
+}
+
+// LoadFile loads a v0, v1 or v2 schema file and registers it under the name
+func (r *Registry) LoadFile(name string, path string) error {
+	schematics, err := r.loadFile(path)
+	if err != nil {
+		r.Logging.ERROR("Failed to load schema file", path, err)
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	_, _, err = r.register(name, path, schematics)
+	return err
+}
+
+// LoadFS registers every json and yaml schema file of the file system matching the glob pattern like LoadDir,
+// "schemas/**" loads the schemas folder of an embed.FS and the names are relative to the root of the file system
+func (r *Registry) LoadFS(fsys fs.FS, pattern string) error {
+	names, err := utils.GlobFS(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if !isSchemaFile(name) {
+			continue
+		}
+		schematics, err := r.load(fsys, name)
+		if err != nil {
+			r.Logging.ERROR("Failed to load schema file", name, err)
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if _, _, err := r.register(SchemaName(name), name, schematics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Registry) loadFile(path string) (*v0.Schematics, error) {
+	return r.load(nil, path)
+}
+
+// load reads the file from the file system, or from the disk when fsys is nil
+func (r *Registry) load(fsys fs.FS, path string) (*v0.Schematics, error) {
+	var content []byte
+	var err error
+	if fsys == nil {
+		content, err = os.ReadFile(path)
+	} else {
+		content, err = fs.ReadFile(fsys, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	format := utils.DetectFormat(path, content)
+	version, err := utils.DetectSchemaVersion(content, format)
+	if err != nil {
+		return nil, err
+	}
+
+	var schematics *v0.Schematics
+	switch version {
+	case utils.SchemaV0:
+		r.mutex.Lock()
+		r.init()
+		schematics = &v0.Schematics{
+			Validators: r.Validators,
+			Operators:  r.Operators,
+			Separator:  r.Separator,
+			Locale:     r.Locale,
+			Logging:    r.Logging,
+		}
+		r.mutex.Unlock()
+		if fsys == nil {
+			err = schematics.LoadSchemaFile(path)
+		} else {
+			err = schematics.LoadSchemaFS(fsys, path)
+		}
+	case utils.SchemaV1:
+		schematics, err = v1.LoadSchemaReader(bytes.NewReader(content), format)
+	default:
+		schematics, err = v2.LoadSchemaReader(bytes.NewReader(content), format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if schematics.Separator == "" {
+		schematics.Separator = r.Separator
+	}
+	if schematics.Separator == "" {
+		schematics.Separator = "."
+	}
+	if schematics.Locale == "" {
+		schematics.Locale = r.Locale
+	}
+	if schematics.Locale == "" {
+		schematics.Locale = "en"
+	}
+	schematics.Logging = r.Logging
+	return schematics, nil
+}
+
+// SchemaName is the path without the extension and the version suffix, with forward slashes
+func SchemaName(path string) string {
+	name := filepath.ToSlash(strings.TrimSuffix(path, filepath.Ext(path)))
+	if index := strings.LastIndex(name, "@"); index > strings.LastIndex(name, "/") {
+		name = name[:index]
+	}
+	return name
+}
+
+func isSchemaFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+package registry
+
+import (
+	"fmt"
+	v0 "github.com/ashbeelghouri/jsonschematics/data/v0"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Watcher polls schema files and directories and swaps the changed schemas into the registry,
+// a schema that fails to load or to compile is reported through OnError and the last good version stays registered,
+// the schematics already returned by Get are never modified so validations in flight finish on the old version
+type Watcher struct {
+	Registry *Registry
+	// Interval between two polls, 2 seconds by default
+	Interval time.Duration
+	OnError  func(path string, err error)
+	OnReload func(entry *Entry)
+	mutex    sync.Mutex
+	roots    []watchRoot
+	states   map[string]fileState
+	loaded   map[string]*Entry
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+type watchRoot struct {
+	path string
+	name string
+	dir  bool
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// AddDir loads the schema files of the directory tree like LoadDir and watches them,
+// files added to the directory later are picked up by the next poll, none of the files is registered when one of them fails
+func (w *Watcher) AddDir(dir string) error {
+	return w.add(watchRoot{path: dir, dir: true})
+}
+
+// AddFile loads the schema file under the name and watches it
+func (w *Watcher) AddFile(name string, path string) error {
+	return w.add(watchRoot{path: path, name: name})
+}
+
+func (w *Watcher) add(root watchRoot) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.Registry == nil {
+		w.Registry = &Registry{}
+	}
+	if w.states == nil {
+		w.states = make(map[string]fileState)
+		w.loaded = make(map[string]*Entry)
+	}
+	files, err := root.files()
+	if err != nil {
+		return err
+	}
+	// every file is compiled before the first one is registered so a broken file leaves the registry as it was
+	paths := sortedPaths(files)
+	compiled := make([]*v0.Schematics, len(paths))
+	for i, path := range paths {
+		if compiled[i], err = w.compile(path); err != nil {
+			return err
+		}
+	}
+	// the entries replaced by the installed files are kept to put them back when a later file fails
+	loaded := make(map[string]*Entry, len(w.loaded))
+	for path, entry := range w.loaded {
+		loaded[path] = entry
+	}
+	states := make(map[string]fileState, len(w.states))
+	for path, state := range w.states {
+		states[path] = state
+	}
+	installed := make(map[*Entry]bool)
+	var replaced []*Entry
+	for i, path := range paths {
+		entry, previous, err := w.install(root, path, compiled[i])
+		if err != nil {
+			for entry := range installed {
+				w.Registry.Remove(entry.Name, entry.Version)
+			}
+			for j := len(replaced) - 1; j >= 0; j-- {
+				if !installed[replaced[j]] {
+					w.Registry.restore(replaced[j])
+				}
+			}
+			w.loaded, w.states = loaded, states
+			return err
+		}
+		installed[entry] = true
+		replaced = append(replaced, previous...)
+		w.states[path] = files[path]
+	}
+	w.roots = append(w.roots, root)
+	return nil
+}
+
+// Start polls the watched files in the background until Stop is called
+func (w *Watcher) Start() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.stop != nil {
+		return
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	go func(stop chan struct{}, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				w.Check()
+			}
+		}
+	}(w.stop, w.done)
+}
+
+func (w *Watcher) Stop() {
+	w.mutex.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mutex.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// Check polls the watched files once, a change in a directory reloads all of its files
+// because the schemas can extend or include each other
+func (w *Watcher) Check() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, root := range w.roots {
+		files, err := root.files()
+		if err != nil {
+			w.report(root.path, err)
+			continue
+		}
+		changed := false
+		for path, state := range files {
+			if previous, exists := w.states[path]; !exists || previous != state {
+				changed = true
+			}
+		}
+		for path := range w.states {
+			if _, exists := files[path]; !exists && root.contains(path) {
+				changed = true
+				delete(w.states, path)
+				if entry := w.loaded[path]; entry != nil {
+					w.Registry.Remove(entry.Name, entry.Version)
+					delete(w.loaded, path)
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+		for _, path := range sortedPaths(files) {
+			w.states[path] = files[path]
+			if err := w.load(root, path); err != nil {
+				w.report(path, err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) load(root watchRoot, path string) error {
+	schematics, err := w.compile(path)
+	if err != nil {
+		return err
+	}
+	_, _, err = w.install(root, path, schematics)
+	return err
+}
+
+func (w *Watcher) compile(path string) (*v0.Schematics, error) {
+	schematics, err := w.Registry.loadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if errs := schematics.Compile(); errs.HasErrors() {
+		return nil, fmt.Errorf("%s: %w", path, errs)
+	}
+	return schematics, nil
+}
+
+// install registers the schematics of the file and returns the entries it replaced or removed
+func (w *Watcher) install(root watchRoot, path string, schematics *v0.Schematics) (*Entry, []*Entry, error) {
+	name := root.name
+	if root.dir {
+		relative, err := filepath.Rel(root.path, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		name = SchemaName(relative)
+	}
+	entry, replaced, err := w.Registry.register(name, path, schematics)
+	if err != nil {
+		return nil, nil, err
+	}
+	var removed []*Entry
+	if replaced != nil {
+		removed = append(removed, replaced)
+	}
+	if previous := w.loaded[path]; previous != nil && (previous.Name != entry.Name || previous.Version != entry.Version) {
+		w.Registry.Remove(previous.Name, previous.Version)
+		removed = append(removed, previous)
+	}
+	w.loaded[path] = entry
+	if w.OnReload != nil {
+		w.OnReload(entry)
+	}
+	return entry, removed, nil
+}
+
+func (w *Watcher) report(path string, err error) {
+	w.Registry.Logging.ERROR("Failed to reload schema file", path, err)
+	if w.OnError != nil {
+		w.OnError(path, err)
+	}
+}
+
+func (root watchRoot) files() (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	if !root.dir {
+		info, err := os.Stat(root.path)
+		if err != nil {
+			return nil, err
+		}
+		files[root.path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return files, nil
+	}
+	err := filepath.WalkDir(root.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isSchemaFile(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return files, err
+}
+
+func (root watchRoot) contains(path string) bool {
+	if !root.dir {
+		return path == root.path
+	}
+	relative, err := filepath.Rel(root.path, path)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
+
+func sortedPaths(files map[string]fileState) []string {
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
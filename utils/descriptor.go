@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	TypeAny     = "any"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+	TypeDate    = "date"
+	TypeArray   = "array"
+	TypeObject  = "object"
+)
+
+// limits tell which way an attribute restricts the value, they are used to classify schema changes
+const (
+	LimitMax     = "max"
+	LimitMin     = "min"
+	LimitOptions = "options"
+)
+
+type Attribute struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description,omitempty"`
+	Limit       string `json:"limit,omitempty"`
+}
+
+type Descriptor struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Attributes  []Attribute `json:"attributes"`
+	AppliesTo   []string    `json:"applies_to"`
+	// ComparesField marks the validators which get the value of another field through the field or sibling attribute
+	ComparesField bool `json:"compares_field,omitempty"`
+}
+
+func (d *Descriptor) GetAttribute(name string) *Attribute {
+	for i := range d.Attributes {
+		if d.Attributes[i].Name == name {
+			return &d.Attributes[i]
+		}
+	}
+	return nil
+}
+
+// CheckAttributes returns the problems found in the attributes keyed by the attribute name
+func (d *Descriptor) CheckAttributes(attributes map[string]interface{}) map[string]string {
+	problems := make(map[string]string)
+	for _, attr := range d.Attributes {
+		value, exists := attributes[attr.Name]
+		if !exists || value == nil {
+			if attr.Required {
+				problems[attr.Name] = "required attribute is missing"
+			}
+			continue
+		}
+		if !ValueIsOfType(value, attr.Type) {
+			problems[attr.Name] = fmt.Sprintf("attribute should be of type %s", attr.Type)
+		}
+	}
+	return problems
+}
+
+// AppliesToType checks if the descriptor can be used on a field of the given type,
+// unknown field types are always accepted
+func (d *Descriptor) AppliesToType(fieldType string) bool {
+	if len(d.AppliesTo) == 0 || !StringInStrings(fieldType, []string{TypeString, TypeNumber, TypeInteger, TypeBoolean, TypeDate, TypeArray, TypeObject}) {
+		return true
+	}
+	for _, t := range d.AppliesTo {
+		switch {
+		case t == TypeAny, t == fieldType:
+			return true
+		case t == TypeNumber && fieldType == TypeInteger:
+			return true
+		case t == TypeString && fieldType == TypeDate, t == TypeDate && fieldType == TypeString:
+			return true
+		}
+	}
+	return false
+}
+
+// RequiredAttributes maps the names of the descriptors to the attributes which are required
+func RequiredAttributes(descriptors []Descriptor) map[string][]string {
+	required := make(map[string][]string)
+	for _, descriptor := range descriptors {
+		for _, attr := range descriptor.Attributes {
+			if attr.Required {
+				required[descriptor.Name] = append(required[descriptor.Name], attr.Name)
+			}
+		}
+	}
+	return required
+}
+
+func ValueIsOfType(value interface{}, t string) bool {
+	switch t {
+	case TypeString, TypeDate:
+		_, ok := value.(string)
+		return ok
+	case TypeNumber:
+		_, ok := value.(float64)
+		return ok
+	case TypeInteger:
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case TypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case TypeArray:
+		if value == nil {
+			return false
+		}
+		kind := reflect.TypeOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	case TypeObject:
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		return true
+	}
+}
@@ -0,0 +1,416 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatJson = "json"
+	FormatYaml = "yaml"
+)
+
+const (
+	SchemaV0 = "v0"
+	SchemaV1 = "v1"
+	SchemaV2 = "v2"
+)
+
+// DetectFormat checks the file extension first and falls back to the content of the file
+func DetectFormat(path string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJson
+	case ".yaml", ".yml":
+		return FormatYaml
+	}
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FormatJson
+	}
+	return FormatYaml
+}
+
+// DetectSchemaVersion tells the data schema versions apart by their structure,
+// v0 keeps the fields in a map, v1 keeps the validators of a field in a map and v2 in a list
+func DetectSchemaVersion(content []byte, format string) (string, error) {
+	var schema map[string]interface{}
+	if err := UnmarshalSchema(content, format, &schema); err != nil {
+		return "", err
+	}
+	switch fields := schema["fields"].(type) {
+	case map[string]interface{}:
+		return SchemaV0, nil
+	case []interface{}:
+		for _, f := range fields {
+			field, ok := f.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, key := range []string{"validators", "operators"} {
+				switch field[key].(type) {
+				case map[string]interface{}:
+					return SchemaV1, nil
+				case []interface{}:
+					return SchemaV2, nil
+				}
+			}
+		}
+		return SchemaV2, nil
+	case nil:
+		if _, ok := schema["extends"]; ok {
+			return SchemaV0, nil
+		}
+		if _, ok := schema["includes"]; ok {
+			return SchemaV0, nil
+		}
+	}
+	return "", errors.New("schema version could not be detected")
+}
+
+// YamlToJson converts the yaml document into json, the keys of the mappings keep their order
+func YamlToJson(content []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	if err := writeYamlNode(&buffer, &node); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeYamlNode(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buffer.WriteString("null")
+			return nil
+		}
+		return writeYamlNode(buffer, node.Content[0])
+	case yaml.AliasNode:
+		return writeYamlNode(buffer, node.Alias)
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := writeYamlNode(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return nil
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			// merge keys are left to the decoder
+			if node.Content[i].Tag == "!!merge" {
+				return writeDecodedYamlNode(buffer, node)
+			}
+		}
+		buffer.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buffer.Write(key)
+			buffer.WriteByte(':')
+			if err := writeYamlNode(buffer, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return nil
+	}
+	return writeDecodedYamlNode(buffer, node)
+}
+
+func writeDecodedYamlNode(buffer *bytes.Buffer, node *yaml.Node) error {
+	var data interface{}
+	if err := node.Decode(&data); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	buffer.Write(encoded)
+	return nil
+}
+
+// KeyOrder is the order of the keys of every object of a document, an object is found by the keys
+// and the array indexes leading to it
+type KeyOrder map[string][]string
+
+func ReadKeyOrder(content []byte, format string) (KeyOrder, error) {
+	if format == FormatYaml {
+		var err error
+		content, err = YamlToJson(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	order := make(KeyOrder)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if err := order.read(decoder, nil); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Keys returns the keys of the object in the order of the document, without duplicates
+func (k KeyOrder) Keys(path ...string) []string {
+	return k[strings.Join(path, "\x00")]
+}
+
+// OrderKeys lists the keys in the order, the keys missing from the order are sorted and come last
+func OrderKeys(order []string, keys []string) []string {
+	var ordered, rest []string
+	for _, key := range order {
+		if StringInStrings(key, keys) && !StringInStrings(key, ordered) {
+			ordered = append(ordered, key)
+		}
+	}
+	for _, key := range keys {
+		if !StringInStrings(key, ordered) {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(ordered, rest...)
+}
+
+func (k KeyOrder) read(decoder *json.Decoder, path []string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+	switch delim {
+	case '{':
+		var keys []string
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			if !StringInStrings(key, keys) {
+				keys = append(keys, key)
+			}
+			if err := k.read(decoder, append(path[:len(path):len(path)], key)); err != nil {
+				return err
+			}
+		}
+		k[strings.Join(path, "\x00")] = keys
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if err := k.read(decoder, append(path[:len(path):len(path)], strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// MarshalSchema produces stable, pretty printed json or yaml, map keys are sorted
+// and the order of the struct fields, the lists and the OrderedObjects is preserved
+func MarshalSchema(v interface{}, format string) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	if format != FormatYaml {
+		return buffer.Bytes(), nil
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(buffer.Bytes(), &node); err != nil {
+		return nil, err
+	}
+	resetYamlStyle(&node)
+	var out bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&out)
+	yamlEncoder.SetIndent(2)
+	if err := yamlEncoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := yamlEncoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// OrderedObject is a json object which keeps the order of its entries, the schemas use it to write the validators
+// in the order they are declared
+type OrderedObject []ObjectEntry
+
+type ObjectEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// NewOrderedObject lists the entries of a map with string keys in the order, the keys missing from the order are sorted
+func NewOrderedObject(m interface{}, order []string) OrderedObject {
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Map || value.Len() == 0 {
+		return nil
+	}
+	entries := make(map[string]interface{}, value.Len())
+	keys := make([]string, 0, value.Len())
+	iterator := value.MapRange()
+	for iterator.Next() {
+		key := iterator.Key().String()
+		entries[key] = iterator.Value().Interface()
+		keys = append(keys, key)
+	}
+	var object OrderedObject
+	for _, key := range OrderKeys(order, keys) {
+		object = append(object, ObjectEntry{Key: key, Value: entries[key]})
+	}
+	return object
+}
+
+func (o OrderedObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, entry := range o {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, err := MarshalJSON(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := MarshalJSON(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// MarshalJSON is json.Marshal without escaping the html characters, like the encoder of MarshalSchema
+func MarshalJSON(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buffer.Bytes(), "\n"), nil
+}
+
+// resetYamlStyle drops the flow style and the quotes which are kept from the json document
+func resetYamlStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYamlStyle(child)
+	}
+}
+
+// UnmarshalSchema unmarshalls the json or yaml content into v,
+// errors mention the line of the offending field
+func UnmarshalSchema(content []byte, format string, v interface{}) error {
+	jsonContent := content
+	if format == FormatYaml {
+		var err error
+		jsonContent, err = YamlToJson(content)
+		if err != nil {
+			return err
+		}
+	}
+	err := json.Unmarshal(jsonContent, v)
+	if err == nil {
+		return nil
+	}
+
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+	switch {
+	case errors.As(err, &typeError) && format == FormatYaml:
+		if line := yamlLine(content, typeError.Field); line > 0 {
+			return fmt.Errorf("line %d: %s should be of type %s: %w", line, typeError.Field, typeError.Type, err)
+		}
+	case errors.As(err, &typeError):
+		return fmt.Errorf("line %d: %s should be of type %s: %w", offsetLine(content, typeError.Offset), typeError.Field, typeError.Type, err)
+	case errors.As(err, &syntaxError) && format == FormatJson:
+		return fmt.Errorf("line %d: %w", offsetLine(content, syntaxError.Offset), err)
+	}
+	return err
+}
+
+func offsetLine(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+// yamlLine finds the line of the dotted field path, map keys may contain dots as well
+func yamlLine(content []byte, field string) int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 {
+		return 0
+	}
+	node := doc.Content[0]
+	segments := strings.Split(field, ".")
+	line := node.Line
+	for len(segments) > 0 {
+		switch node.Kind {
+		case yaml.MappingNode:
+			found := false
+			for size := len(segments); size > 0 && !found; size-- {
+				key := strings.Join(segments[:size], ".")
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == key {
+						line = node.Content[i].Line
+						node = node.Content[i+1]
+						segments = segments[size:]
+						found = true
+						break
+					}
+				}
+			}
+			if !found {
+				return line
+			}
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(segments[0])
+			if err != nil || index >= len(node.Content) {
+				return line
+			}
+			node = node.Content[index]
+			line = node.Line
+			segments = segments[1:]
+		default:
+			return line
+		}
+	}
+	return line
+}
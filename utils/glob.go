@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// GlobFS lists the files of the file system matching the pattern in lexical order, the pattern uses the syntax of path.Match
+// and ** matches any number of directories, so "schemas/**/*.json" matches every json file under schemas,
+// an empty pattern matches every file
+func GlobFS(fsys fs.FS, pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = "**"
+	}
+	patterns := strings.Split(path.Clean(strings.TrimPrefix(pattern, "/")), "/")
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, err
+		}
+	}
+	var matches []string
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || name == "." {
+			return nil
+		}
+		if matchSegments(patterns, strings.Split(name, "/")) {
+			matches = append(matches, name)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+func matchSegments(patterns []string, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(patterns[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(patterns[0], segments[0]); !matched {
+		return false
+	}
+	return matchSegments(patterns[1:], segments[1:])
+}
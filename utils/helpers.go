@@ -16,6 +16,14 @@ var ExcludedValidators = []string{
 	"ISREQUIRED",
 }
 
+// TypeValidators check the type of the value, they run before the other validators of a field
+var TypeValidators = []string{
+	"IsString",
+	"IsNumber",
+	"IsInteger",
+	"IsValidDate",
+}
+
 type DataMap struct {
 	Data map[string]interface{}
 }
@@ -32,16 +40,26 @@ func (d *DataMap) FlattenTheMap(data map[string]interface{}, prefix string, sepa
 		if prefix != "" {
 			newKey = prefix + separator + key
 		}
+		if value == nil {
+			d.Data[newKey] = nil
+			continue
+		}
+		// empty arrays and objects are kept as values, so they are not mistaken for missing keys
 		switch reflect.TypeOf(value).Kind() {
 		case reflect.Map:
-			if nestedMap, ok := value.(map[string]interface{}); ok {
+			if nestedMap, ok := value.(map[string]interface{}); ok && len(nestedMap) > 0 {
 				d.FlattenTheMap(nestedMap, newKey, separator)
+			} else {
+				d.Data[newKey] = value
 			}
 		case reflect.Slice:
 			s := reflect.ValueOf(value)
+			if s.Len() == 0 {
+				d.Data[newKey] = value
+			}
 			for i := 0; i < s.Len(); i++ {
 				arrayKey := newKey + separator + strconv.Itoa(i)
-				if nestedMap, ok := s.Index(i).Interface().(map[string]interface{}); ok {
+				if nestedMap, ok := s.Index(i).Interface().(map[string]interface{}); ok && len(nestedMap) > 0 {
 					d.FlattenTheMap(nestedMap, arrayKey, separator)
 				} else {
 					d.Data[arrayKey] = s.Index(i).Interface()
@@ -79,6 +97,15 @@ func DeflateMap(data map[string]interface{}, separator string) map[string]interf
 				}
 				subMap[key] = slice
 
+				// the items of an array of values are set directly
+				if i+1 == len(keys)-1 {
+					slice[index] = value
+					subMap = nil
+					break
+				}
+				if _, ok := slice[index].(map[string]interface{}); !ok {
+					slice[index] = map[string]interface{}{}
+				}
 				subMap = slice[index].(map[string]interface{})
 				i++
 			} else {
@@ -90,12 +117,27 @@ func DeflateMap(data map[string]interface{}, separator string) map[string]interf
 			}
 		}
 
-		subMap[keys[len(keys)-1]] = value
+		if subMap != nil {
+			subMap[keys[len(keys)-1]] = value
+		}
 	}
 
 	return result
 }
 
+// IsEmptyValue is true for empty strings, arrays and objects, null is not empty
+func IsEmptyValue(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
 func IsNumeric(s string) bool {
 	_, err := strconv.Atoi(s)
 	return err == nil
@@ -140,6 +182,86 @@ func FindMatchingKeys(data map[string]interface{}, keyPattern string) map[string
 	return matchingKeys
 }
 
+// FindContainers rebuilds the arrays and the objects matching the key pattern from the keys nested under them,
+// the shape comes from the original data so objects with numeric keys stay objects, and the values come from the flat data
+func FindContainers(data map[string]interface{}, original map[string]interface{}, keyPattern string, separator string) map[string]interface{} {
+	if separator == "" {
+		separator = "."
+	}
+	re := regexp.MustCompile("^(" + strings.TrimSuffix(strings.TrimPrefix(ConvertKeyToRegex(keyPattern), "^"), "$") + ")" + regexp.QuoteMeta(separator))
+	containers := make(map[string]interface{})
+	for key := range data {
+		match := re.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		if _, exists := containers[match[1]]; exists {
+			continue
+		}
+		if value, found := lookupPath(original, strings.Split(match[1], separator)); found {
+			containers[match[1]] = rebuildContainer(value, match[1], data, separator)
+		}
+	}
+	return containers
+}
+
+// lookupPath walks the nested maps and slices along the keys of a flat key
+func lookupPath(value interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		if m, ok := value.(map[string]interface{}); ok {
+			if value, ok = m[key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+		s := reflect.ValueOf(value)
+		if s.Kind() != reflect.Slice {
+			return nil, false
+		}
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= s.Len() {
+			return nil, false
+		}
+		value = s.Index(index).Interface()
+	}
+	return value, true
+}
+
+// rebuildContainer copies the arrays and the objects of the value like FlattenTheMap walks them,
+// the values found in the flat data replace the original ones
+func rebuildContainer(value interface{}, key string, data map[string]interface{}, separator string) interface{} {
+	if flat, exists := data[key]; exists {
+		return flat
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		rebuilt := make(map[string]interface{}, len(m))
+		for k, item := range m {
+			rebuilt[k] = rebuildContainer(item, key+separator+k, data, separator)
+		}
+		return rebuilt
+	}
+	if s := reflect.ValueOf(value); s.Kind() == reflect.Slice {
+		rebuilt := make([]interface{}, s.Len())
+		for i := range rebuilt {
+			rebuilt[i] = rebuildContainer(s.Index(i).Interface(), key+separator+strconv.Itoa(i), data, separator)
+		}
+		return rebuilt
+	}
+	return value
+}
+
+// HasNestedKeys checks if the data has any key nested under the key pattern
+func HasNestedKeys(data map[string]interface{}, keyPattern string, separator string) bool {
+	pattern := strings.TrimSuffix(ConvertKeyToRegex(keyPattern), "$") + regexp.QuoteMeta(separator)
+	re := regexp.MustCompile(pattern)
+	for key := range data {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
 func IsValidJson(content []byte) (string, interface{}) {
 	var arr []map[string]interface{}
 	var obj map[string]interface{}
@@ -156,12 +278,31 @@ func IsValidJson(content []byte) (string, interface{}) {
 	return "invalid format", nil
 }
 
+var pathParamRegex = regexp.MustCompile(`:[^/]+`)
+
 func GetPathRegex(path string) string {
 	path = strings.ReplaceAll(path, "*", ".*")
-	path = strings.ReplaceAll(path, ":", "[^/]+")
+	path = pathParamRegex.ReplaceAllString(path, "[^/]+")
 	return "^" + path + "$"
 }
 
+func GetPathParams(pattern string, path string) map[string]interface{} {
+	params := make(map[string]interface{})
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, ":") && i < len(pathSegments) {
+			params[segment[1:]] = pathSegments[i]
+		}
+	}
+	return params
+}
+
+// EscapeJsonPointer escapes a key to be used as a segment of a json pointer
+func EscapeJsonPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
 func FormatError(id *string, message string, target string, validator string, value string, format string, data *map[string]interface{}) string {
 	errorMessage := strings.Replace(format, "%message", message, -1)
 	errorMessage = strings.Replace(errorMessage, "%target", target, -1)
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSemver reads MAJOR.MINOR.PATCH with an optional v prefix, pre-release and build metadata are ignored
+func ParseSemver(version string) ([3]int, error) {
+	parsed, count, err := parsePartialSemver(version)
+	if err != nil || count != 3 {
+		return parsed, fmt.Errorf("%q is not a semantic version", version)
+	}
+	return parsed, nil
+}
+
+// CompareSemver returns -1, 0 or 1 when a is lower than, equal to or greater than b
+func CompareSemver(a [3]int, b [3]int) int {
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		}
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// SemverMatches checks the version against a range like "1.2.3", "^1.2", "~1.2.0", "1.x", ">=1.0.0 <2.0.0",
+// comparators separated by spaces must all match and ranges separated by || are alternatives
+func SemverMatches(version string, constraint string) (bool, error) {
+	v, err := ParseSemver(version)
+	if err != nil {
+		return false, err
+	}
+	for _, alternative := range strings.Split(constraint, "||") {
+		matched := true
+		comparators := strings.Fields(alternative)
+		if len(comparators) == 0 {
+			comparators = []string{"*"}
+		}
+		for _, comparator := range comparators {
+			ok, err := matchComparator(v, comparator)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchComparator(v [3]int, comparator string) (bool, error) {
+	operator := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(comparator, prefix) {
+			operator = prefix
+			break
+		}
+	}
+	bound, count, err := parsePartialSemver(strings.TrimPrefix(comparator, operator))
+	if err != nil {
+		return false, fmt.Errorf("invalid version range %q", comparator)
+	}
+	compared := CompareSemver(v, bound)
+
+	switch operator {
+	case ">=":
+		return compared >= 0, nil
+	case ">":
+		if count < 3 {
+			return CompareSemver(v, upperBound(bound, count)) >= 0, nil
+		}
+		return compared > 0, nil
+	case "<=":
+		if count < 3 {
+			return CompareSemver(v, upperBound(bound, count)) < 0, nil
+		}
+		return compared <= 0, nil
+	case "<":
+		return compared < 0, nil
+	case "^":
+		if compared < 0 {
+			return false, nil
+		}
+		switch {
+		case bound[0] > 0 || count == 1:
+			return CompareSemver(v, upperBound(bound, 1)) < 0, nil
+		case bound[1] > 0 || count == 2:
+			return CompareSemver(v, upperBound(bound, 2)) < 0, nil
+		}
+		return compared == 0, nil
+	case "~":
+		if compared < 0 {
+			return false, nil
+		}
+		if count == 1 {
+			return CompareSemver(v, upperBound(bound, 1)) < 0, nil
+		}
+		return CompareSemver(v, upperBound(bound, 2)) < 0, nil
+	}
+	if count == 3 {
+		return compared == 0, nil
+	}
+	return compared >= 0 && CompareSemver(v, upperBound(bound, count)) < 0, nil
+}
+
+// upperBound is the first version after the partial version, e.g. 1.3.0 for 1.2
+func upperBound(bound [3]int, count int) [3]int {
+	if count == 0 {
+		return [3]int{int(^uint(0) >> 1), 0, 0}
+	}
+	upper := [3]int{}
+	copy(upper[:count], bound[:count])
+	upper[count-1]++
+	return upper
+}
+
+// parsePartialSemver returns the version and how many of its parts are given, x and * are wildcards
+func parsePartialSemver(version string) ([3]int, int, error) {
+	var parsed [3]int
+	core := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), "-", 2)[0]
+	core = strings.SplitN(core, "+", 2)[0]
+	if core == "" || core == "*" || core == "x" || core == "X" {
+		return parsed, 0, nil
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return parsed, 0, fmt.Errorf("%q is not a semantic version", version)
+	}
+	count := 0
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return parsed, 0, fmt.Errorf("%q is not a semantic version", version)
+		}
+		parsed[i] = number
+		count++
+	}
+	return parsed, count, nil
+}
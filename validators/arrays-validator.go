@@ -70,3 +70,47 @@ func SpecificStringIsProvidedInArray(i interface{}, attr map[string]interface{})
 	}
 	return fmt.Errorf("the string %s is not provided in the array", shouldExist)
 }
+
+// ArrayUniqueItems checks that no item is repeated, the key attribute compares a property of the object items
+func ArrayUniqueItems(i interface{}, attr map[string]interface{}) error {
+	if !isArray(i) {
+		return errors.New("only arrays are allowed")
+	}
+	key, _ := attr["key"].(string)
+	items := reflect.ValueOf(i)
+	var seen []interface{}
+	for index := 0; index < items.Len(); index++ {
+		item := items.Index(index).Interface()
+		if key != "" {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("item %d is not an object with %s", index, key)
+			}
+			item = object[key]
+		}
+		for _, previous := range seen {
+			if reflect.DeepEqual(previous, item) {
+				return fmt.Errorf("array items should be unique, (%v) is repeated", item)
+			}
+		}
+		seen = append(seen, item)
+	}
+	return nil
+}
+
+func ArrayContains(i interface{}, attr map[string]interface{}) error {
+	if !isArray(i) {
+		return errors.New("only arrays are allowed")
+	}
+	value, ok := attr["value"]
+	if !ok {
+		return errors.New("attribute 'value' is not provided")
+	}
+	items := reflect.ValueOf(i)
+	for index := 0; index < items.Len(); index++ {
+		if reflect.DeepEqual(items.Index(index).Interface(), value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("array should contain (%v)", value)
+}
@@ -0,0 +1,66 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coerce converts a string into the type of the field, query strings, headers and forms only have strings.
+// Values which are not strings, empty strings and the types without a conversion are returned as they are
+func Coerce(i interface{}, fieldType string) (interface{}, error) {
+	str, ok := i.(string)
+	if !ok || str == "" {
+		return i, nil
+	}
+	trimmed := strings.TrimSpace(str)
+	switch fieldType {
+	case utils.TypeNumber:
+		number, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return i, fmt.Errorf("(%s) can not be converted to a number", str)
+		}
+		return number, nil
+	case utils.TypeInteger:
+		number, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil || number != math.Trunc(number) || math.IsInf(number, 0) {
+			return i, fmt.Errorf("(%s) can not be converted to an integer", str)
+		}
+		return number, nil
+	case utils.TypeBoolean:
+		switch strings.ToLower(trimmed) {
+		case "true", "t", "1", "yes", "y", "on":
+			return true, nil
+		case "false", "f", "0", "no", "n", "off":
+			return false, nil
+		}
+		return i, fmt.Errorf("(%s) can not be converted to a boolean", str)
+	case utils.TypeDate:
+		// dates stay strings for the date validators, unix timestamps become RFC 3339 dates
+		if InterfaceToDate(trimmed) != nil {
+			return trimmed, nil
+		}
+		if seconds, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC().Format(time.RFC3339), nil
+		}
+		return i, fmt.Errorf("(%s) can not be converted to a date", str)
+	case utils.TypeArray:
+		if strings.HasPrefix(trimmed, "[") {
+			var items []interface{}
+			if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+				return i, fmt.Errorf("(%s) can not be converted to an array", str)
+			}
+			return items, nil
+		}
+		var items []interface{}
+		for _, item := range strings.Split(str, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+		return items, nil
+	}
+	return i, nil
+}
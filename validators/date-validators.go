@@ -7,7 +7,10 @@ import (
 )
 
 func InterfaceToDate(i interface{}) *time.Time {
-	dateStr := i.(string)
+	dateStr, ok := i.(string)
+	if !ok {
+		return nil
+	}
 	layouts := []string{
 		"2006-01-02",
 		time.Layout,
@@ -0,0 +1,327 @@
+package validators
+
+import "github.com/ashbeelghouri/jsonschematics/utils"
+
+var stringOnly = []string{utils.TypeString}
+var numberOnly = []string{utils.TypeNumber}
+var dateOnly = []string{utils.TypeDate}
+var arrayOnly = []string{utils.TypeArray}
+var objectOnly = []string{utils.TypeObject}
+var comparableTypes = []string{utils.TypeString, utils.TypeNumber, utils.TypeDate}
+
+var fieldAttributes = []utils.Attribute{
+	{Name: FieldAttribute, Type: utils.TypeString, Description: "target key of the other field, wildcards are the items of the validated value"},
+	{Name: SiblingAttribute, Type: utils.TypeString, Description: "key of the other field in the same object as the value"},
+}
+
+var BasicDescriptors = []utils.Descriptor{
+	// String Validators
+	{
+		Name:        "IsString",
+		Description: "value should be a string",
+		AppliesTo:   []string{utils.TypeAny},
+	},
+	{
+		Name:        "NotEmpty",
+		Description: "string should not be empty or only have white spaces",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "StringTakenFromOptions",
+		Description: "string should be one of the provided options",
+		Attributes: []utils.Attribute{
+			{Name: "options", Type: utils.TypeArray, Required: true, Description: "list of the allowed strings", Limit: utils.LimitOptions},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "IsEmail",
+		Description: "string should be a valid email address",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "MaxLengthAllowed",
+		Description: "length of the string should not be greater than max",
+		Attributes: []utils.Attribute{
+			{Name: "max", Type: utils.TypeNumber, Required: true, Description: "maximum allowed length", Limit: utils.LimitMax},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "MinLengthAllowed",
+		Description: "length of the string should not be less than min",
+		Attributes: []utils.Attribute{
+			{Name: "min", Type: utils.TypeNumber, Required: true, Description: "minimum allowed length", Limit: utils.LimitMin},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "InBetweenLengthAllowed",
+		Description: "length of the string should be in between min and max",
+		Attributes: []utils.Attribute{
+			{Name: "min", Type: utils.TypeNumber, Required: true, Description: "minimum allowed length", Limit: utils.LimitMin},
+			{Name: "max", Type: utils.TypeNumber, Required: true, Description: "maximum allowed length", Limit: utils.LimitMax},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "NoSpecialCharacters",
+		Description: "string should not have special characters",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "HaveSpecialCharacters",
+		Description: "string should have at least one special character",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "LeastOneUpperCase",
+		Description: "string should have at least one uppercase letter",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "LeastOneLowerCase",
+		Description: "string should have at least one lowercase letter",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "LeastOneDigit",
+		Description: "string should have at least one numeric digit",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "IsURL",
+		Description: "string should be a valid http or https url",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "IsNotURL",
+		Description: "string should not be a url",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "HaveURLHostName",
+		Description: "url should have the provided hostname",
+		Attributes: []utils.Attribute{
+			{Name: "host", Type: utils.TypeString, Required: true, Description: "hostname the url should end with"},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "HaveQueryParameter",
+		Description: "url should have all the provided query parameters",
+		Attributes: []utils.Attribute{
+			{Name: "params", Type: utils.TypeString, Required: true, Description: "comma separated names of the query parameters"},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "IsHttps",
+		Description: "url should have the https scheme",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "IsValidUuid",
+		Description: "string should be a valid uuid",
+		AppliesTo:   stringOnly,
+	},
+	{
+		Name:        "LIKE",
+		Description: "string should match the sql LIKE pattern",
+		Attributes: []utils.Attribute{
+			{Name: "pattern", Type: utils.TypeString, Required: true, Description: "pattern where % matches any characters and _ matches a single character"},
+		},
+		AppliesTo: stringOnly,
+	},
+	{
+		Name:        "MatchRegex",
+		Description: "string should match the regular expression",
+		Attributes: []utils.Attribute{
+			{Name: "regex", Type: utils.TypeString, Required: true, Description: "regular expression"},
+		},
+		AppliesTo: stringOnly,
+	},
+
+	// Number Validators
+	{
+		Name:        "IsNumber",
+		Description: "value should be a number",
+		AppliesTo:   []string{utils.TypeAny},
+	},
+	{
+		Name:        "IsInteger",
+		Description: "value should be a number without a fraction",
+		AppliesTo:   []string{utils.TypeAny},
+	},
+	{
+		Name:        "MaxAllowed",
+		Description: "number should not be greater than max",
+		Attributes: []utils.Attribute{
+			{Name: "max", Type: utils.TypeNumber, Required: true, Description: "maximum allowed value", Limit: utils.LimitMax},
+		},
+		AppliesTo: numberOnly,
+	},
+	{
+		Name:        "MinAllowed",
+		Description: "number should not be less than min",
+		Attributes: []utils.Attribute{
+			{Name: "min", Type: utils.TypeNumber, Required: true, Description: "minimum allowed value", Limit: utils.LimitMin},
+		},
+		AppliesTo: numberOnly,
+	},
+	{
+		Name:        "InBetween",
+		Description: "number should be in between min and max",
+		Attributes: []utils.Attribute{
+			{Name: "min", Type: utils.TypeNumber, Required: true, Description: "minimum allowed value", Limit: utils.LimitMin},
+			{Name: "max", Type: utils.TypeNumber, Required: true, Description: "maximum allowed value", Limit: utils.LimitMax},
+		},
+		AppliesTo: numberOnly,
+	},
+
+	// Date Validators
+	{
+		Name:        "IsValidDate",
+		Description: "string should be a valid date",
+		AppliesTo:   dateOnly,
+	},
+	{
+		Name:        "IsLessThanNow",
+		Description: "date should be in the future",
+		AppliesTo:   dateOnly,
+	},
+	{
+		Name:        "IsMoreThanNow",
+		Description: "date should be in the past",
+		AppliesTo:   dateOnly,
+	},
+	{
+		Name:        "IsBefore",
+		Description: "date should not be after maxTime",
+		Attributes: []utils.Attribute{
+			{Name: "maxTime", Type: utils.TypeDate, Required: true, Description: "latest allowed date", Limit: utils.LimitMax},
+		},
+		AppliesTo: dateOnly,
+	},
+	{
+		Name:        "IsAfter",
+		Description: "date should not be before maxTime",
+		Attributes: []utils.Attribute{
+			{Name: "maxTime", Type: utils.TypeDate, Required: true, Description: "earliest allowed date", Limit: utils.LimitMin},
+		},
+		AppliesTo: dateOnly,
+	},
+	{
+		Name:        "IsInBetweenTime",
+		Description: "date should be in between minTime and maxTime",
+		Attributes: []utils.Attribute{
+			{Name: "minTime", Type: utils.TypeDate, Required: true, Description: "earliest allowed date", Limit: utils.LimitMin},
+			{Name: "maxTime", Type: utils.TypeDate, Required: true, Description: "latest allowed date", Limit: utils.LimitMax},
+		},
+		AppliesTo: dateOnly,
+	},
+
+	// Field Validators
+	{
+		Name:          "EqualsField",
+		Description:   "value should be equal to the value of another field",
+		Attributes:    fieldAttributes,
+		AppliesTo:     comparableTypes,
+		ComparesField: true,
+	},
+	{
+		Name:          "NotEqualsField",
+		Description:   "value should be different from the value of another field",
+		Attributes:    fieldAttributes,
+		AppliesTo:     comparableTypes,
+		ComparesField: true,
+	},
+	{
+		Name:          "GreaterThanField",
+		Description:   "value should be greater than the value of another field",
+		Attributes:    fieldAttributes,
+		AppliesTo:     comparableTypes,
+		ComparesField: true,
+	},
+	{
+		Name:          "GreaterThanOrEqualField",
+		Description:   "value should not be less than the value of another field",
+		Attributes:    fieldAttributes,
+		AppliesTo:     comparableTypes,
+		ComparesField: true,
+	},
+	{
+		Name:          "LessThanField",
+		Description:   "value should be less than the value of another field",
+		Attributes:    fieldAttributes,
+		AppliesTo:     comparableTypes,
+		ComparesField: true,
+	},
+	{
+		Name:          "LessThanOrEqualField",
+		Description:   "value should not be greater than the value of another field",
+		Attributes:    fieldAttributes,
+		AppliesTo:     comparableTypes,
+		ComparesField: true,
+	},
+
+	// Arrays
+	{
+		Name:        "ArrayLengthMax",
+		Description: "array should not have more items than max",
+		Attributes: []utils.Attribute{
+			{Name: "max", Type: utils.TypeNumber, Required: true, Description: "maximum number of items", Limit: utils.LimitMax},
+		},
+		AppliesTo: arrayOnly,
+	},
+	{
+		Name:        "ArrayLengthMin",
+		Description: "array should not have less items than min",
+		Attributes: []utils.Attribute{
+			{Name: "min", Type: utils.TypeNumber, Required: true, Description: "minimum number of items", Limit: utils.LimitMin},
+		},
+		AppliesTo: arrayOnly,
+	},
+	{
+		Name:        "StringsTakenFromOptions",
+		Description: "every string in the array should be one of the provided options",
+		Attributes: []utils.Attribute{
+			{Name: "options", Type: utils.TypeArray, Required: true, Description: "list of the allowed strings", Limit: utils.LimitOptions},
+		},
+		AppliesTo: arrayOnly,
+	},
+	{
+		Name:        "ArrayUniqueItems",
+		Description: "array items should not be repeated",
+		Attributes: []utils.Attribute{
+			{Name: "key", Type: utils.TypeString, Description: "property of the object items which should be unique"},
+		},
+		AppliesTo: arrayOnly,
+	},
+	{
+		Name:        "ArrayContains",
+		Description: "array should have an item equal to the value",
+		Attributes: []utils.Attribute{
+			{Name: "value", Type: utils.TypeAny, Required: true, Description: "item which should be in the array"},
+		},
+		AppliesTo: arrayOnly,
+	},
+
+	// Objects
+	{
+		Name:        "ObjectPropertiesMax",
+		Description: "object should not have more properties than max",
+		Attributes: []utils.Attribute{
+			{Name: "max", Type: utils.TypeNumber, Required: true, Description: "maximum number of properties", Limit: utils.LimitMax},
+		},
+		AppliesTo: objectOnly,
+	},
+	{
+		Name:        "ObjectPropertiesMin",
+		Description: "object should not have less properties than min",
+		Attributes: []utils.Attribute{
+			{Name: "min", Type: utils.TypeNumber, Required: true, Description: "minimum number of properties", Limit: utils.LimitMin},
+		},
+		AppliesTo: objectOnly,
+	},
+}
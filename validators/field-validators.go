@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// attributes of the validators comparing the value with another value of the document,
+// the validation of the object looks up the field or the sibling and passes its key and value
+const (
+	FieldAttribute       = "field"
+	SiblingAttribute     = "sibling"
+	FieldTargetAttribute = "field_target"
+	FieldValueAttribute  = "field_value"
+)
+
+func EqualsField(i interface{}, attributes map[string]interface{}) error {
+	return compareWithField(i, attributes, "equal to", func(c int) bool { return c == 0 })
+}
+
+func NotEqualsField(i interface{}, attributes map[string]interface{}) error {
+	return compareWithField(i, attributes, "different from", func(c int) bool { return c != 0 })
+}
+
+func GreaterThanField(i interface{}, attributes map[string]interface{}) error {
+	return compareWithField(i, attributes, "greater than", func(c int) bool { return c > 0 })
+}
+
+func GreaterThanOrEqualField(i interface{}, attributes map[string]interface{}) error {
+	return compareWithField(i, attributes, "greater than or equal to", func(c int) bool { return c >= 0 })
+}
+
+func LessThanField(i interface{}, attributes map[string]interface{}) error {
+	return compareWithField(i, attributes, "less than", func(c int) bool { return c < 0 })
+}
+
+func LessThanOrEqualField(i interface{}, attributes map[string]interface{}) error {
+	return compareWithField(i, attributes, "less than or equal to", func(c int) bool { return c <= 0 })
+}
+
+func compareWithField(i interface{}, attributes map[string]interface{}, relation string, accept func(int) bool) error {
+	other, exists := attributes[FieldValueAttribute]
+	if !exists {
+		return fmt.Errorf("no value to compare with, %s or %s attribute is missing", FieldAttribute, SiblingAttribute)
+	}
+	target, _ := attributes[FieldTargetAttribute].(string)
+	compared, err := compareValues(i, other)
+	if err != nil {
+		return err
+	}
+	if !accept(compared) {
+		return fmt.Errorf("(%v) should be %s %s (%v)", i, relation, target, other)
+	}
+	return nil
+}
+
+// compareValues compares numbers, dates and strings, the dates are compared when both strings are dates
+func compareValues(a interface{}, b interface{}) (int, error) {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1, nil
+			case x > y:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			dateA, dateB := InterfaceToDate(x), InterfaceToDate(y)
+			if dateA != nil && dateB != nil {
+				return dateA.Compare(*dateB), nil
+			}
+			return strings.Compare(x, y), nil
+		}
+	}
+	return 0, fmt.Errorf("(%v) can not be compared with (%v)", a, b)
+}
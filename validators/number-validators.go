@@ -3,6 +3,7 @@ package validators
 import (
 	"errors"
 	"fmt"
+	"math"
 )
 
 func IsNumber(i interface{}, _ map[string]interface{}) error {
@@ -12,6 +13,13 @@ func IsNumber(i interface{}, _ map[string]interface{}) error {
 	return nil
 }
 
+func IsInteger(i interface{}, _ map[string]interface{}) error {
+	if number, ok := i.(float64); !ok || number != math.Trunc(number) || math.IsInf(number, 0) {
+		return errors.New(fmt.Sprintf("%v is not an integer", i))
+	}
+	return nil
+}
+
 func MaxAllowed(i interface{}, attributes map[string]interface{}) error {
 	number, ok := i.(float64)
 	if !ok {
@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+)
+
+func ObjectPropertiesMax(i interface{}, attr map[string]interface{}) error {
+	object, ok := i.(map[string]interface{})
+	if !ok {
+		return errors.New("only objects are allowed")
+	}
+	if maxProperties, ok := attr["max"].(float64); !ok || maxProperties < 0 {
+		return errors.New("attribute 'max' must be a non-negative float64")
+	} else if len(object) > int(maxProperties) {
+		return fmt.Errorf("object can not have more than %d properties", int(maxProperties))
+	}
+	return nil
+}
+
+func ObjectPropertiesMin(i interface{}, attr map[string]interface{}) error {
+	object, ok := i.(map[string]interface{})
+	if !ok {
+		return errors.New("only objects are allowed")
+	}
+	if minProperties, ok := attr["min"].(float64); !ok || minProperties < 0 {
+		return errors.New("attribute 'min' must be a non-negative float64")
+	} else if len(object) < int(minProperties) {
+		return fmt.Errorf("object can not have less than %d properties", int(minProperties))
+	}
+	return nil
+}
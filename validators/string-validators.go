@@ -59,24 +59,7 @@ func LIKE(i interface{}, attr map[string]interface{}) error {
 	}
 	pattern, ok := attr["pattern"].(string)
 	if ok {
-		replacer := strings.NewReplacer(
-			".", "\\.",
-			"+", "\\+",
-			"?", "\\?",
-			"(", "\\(",
-			")", "\\)",
-			"[", "\\[",
-			"]", "\\]",
-			"{", "\\{",
-			"}", "\\}",
-			"^", "\\^",
-			"$", "\\$",
-		)
-		regexPattern := replacer.Replace(pattern)
-		regexPattern = strings.ReplaceAll(regexPattern, "%", ".*")
-		regexPattern = strings.ReplaceAll(regexPattern, "_", ".")
-		regexPattern = "^" + regexPattern + "$"
-
+		regexPattern := LikeToRegex(pattern)
 		matched, _ := regexp.MatchString(regexPattern, str)
 
 		if !matched {
@@ -88,6 +71,26 @@ func LIKE(i interface{}, attr map[string]interface{}) error {
 	return nil
 }
 
+func LikeToRegex(pattern string) string {
+	replacer := strings.NewReplacer(
+		".", "\\.",
+		"+", "\\+",
+		"?", "\\?",
+		"(", "\\(",
+		")", "\\)",
+		"[", "\\[",
+		"]", "\\]",
+		"{", "\\{",
+		"}", "\\}",
+		"^", "\\^",
+		"$", "\\$",
+	)
+	regexPattern := replacer.Replace(pattern)
+	regexPattern = strings.ReplaceAll(regexPattern, "%", ".*")
+	regexPattern = strings.ReplaceAll(regexPattern, "_", ".")
+	return "^" + regexPattern + "$"
+}
+
 func IsEmail(i interface{}, attr map[string]interface{}) error {
 	isString := IsString(i, attr)
 	if isString != nil {
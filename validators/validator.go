@@ -1,14 +1,22 @@
 package validators
 
-import "github.com/ashbeelghouri/jsonschematics/utils"
+import (
+	"github.com/ashbeelghouri/jsonschematics/utils"
+	"sort"
+)
 
 type Validators struct {
 	ValidationFns map[string]Validator
+	Descriptors   map[string]utils.Descriptor
 	Logger        utils.Logger
+	basicLoaded   bool
 }
 
 type Validator func(interface{}, map[string]interface{}) error
 
+// RequiredAttributes lists the attributes the basic validators can not work without, it is built from BasicDescriptors
+var RequiredAttributes = utils.RequiredAttributes(BasicDescriptors)
+
 func (v *Validators) RegisterValidator(name string, fn Validator) {
 	v.Logger.DEBUG("registering validator:", name)
 	if v.ValidationFns == nil {
@@ -17,7 +25,36 @@ func (v *Validators) RegisterValidator(name string, fn Validator) {
 	v.ValidationFns[name] = fn
 }
 
+func (v *Validators) DescribeValidator(descriptor utils.Descriptor) {
+	if v.Descriptors == nil {
+		v.Descriptors = make(map[string]utils.Descriptor)
+	}
+	v.Descriptors[descriptor.Name] = descriptor
+}
+
+func (v *Validators) GetDescriptor(name string) (utils.Descriptor, bool) {
+	descriptor, exists := v.Descriptors[name]
+	return descriptor, exists
+}
+
+func (v *Validators) GetDescriptors() []utils.Descriptor {
+	var descriptors []utils.Descriptor
+	for _, descriptor := range v.Descriptors {
+		descriptors = append(descriptors, descriptor)
+	}
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].Name < descriptors[j].Name
+	})
+	return descriptors
+}
+
+// BasicValidators registers the built-in validators once, so registries shared by
+// several schematics are not written to again when a schema is loaded
 func (v *Validators) BasicValidators() {
+	if v.basicLoaded {
+		return
+	}
+	v.basicLoaded = true
 	v.Logger.DEBUG("loading all the basic validators")
 	// String Validators
 	v.RegisterValidator("IsString", IsString)
@@ -37,12 +74,13 @@ func (v *Validators) BasicValidators() {
 	v.RegisterValidator("HaveURLHostName", HaveURLHostName)
 	v.RegisterValidator("HaveQueryParameter", HaveQueryParameter)
 	v.RegisterValidator("IsHttps", IsHttps)
-	v.RegisterValidator("IsURL", IsValidUuid)
+	v.RegisterValidator("IsValidUuid", IsValidUuid)
 	v.RegisterValidator("LIKE", LIKE)
 	v.RegisterValidator("MatchRegex", MatchRegex)
 
 	// Number Validators
 	v.RegisterValidator("IsNumber", IsNumber)
+	v.RegisterValidator("IsInteger", IsInteger)
 	v.RegisterValidator("MaxAllowed", MaxAllowed)
 	v.RegisterValidator("MinAllowed", MinAllowed)
 	v.RegisterValidator("InBetween", InBetween)
@@ -55,10 +93,28 @@ func (v *Validators) BasicValidators() {
 	v.RegisterValidator("IsAfter", IsAfter)
 	v.RegisterValidator("IsInBetweenTime", IsInBetweenTime)
 
+	// Field Validators
+	v.RegisterValidator("EqualsField", EqualsField)
+	v.RegisterValidator("NotEqualsField", NotEqualsField)
+	v.RegisterValidator("GreaterThanField", GreaterThanField)
+	v.RegisterValidator("GreaterThanOrEqualField", GreaterThanOrEqualField)
+	v.RegisterValidator("LessThanField", LessThanField)
+	v.RegisterValidator("LessThanOrEqualField", LessThanOrEqualField)
+
 	//Arrays
 	v.RegisterValidator("ArrayLengthMax", ArrayLengthMax)
 	v.RegisterValidator("ArrayLengthMin", ArrayLengthMin)
 	v.RegisterValidator("StringsTakenFromOptions", StringsTakenFromOptions)
+	v.RegisterValidator("ArrayUniqueItems", ArrayUniqueItems)
+	v.RegisterValidator("ArrayContains", ArrayContains)
+
+	//Objects
+	v.RegisterValidator("ObjectPropertiesMax", ObjectPropertiesMax)
+	v.RegisterValidator("ObjectPropertiesMin", ObjectPropertiesMin)
+
+	for _, descriptor := range BasicDescriptors {
+		v.DescribeValidator(descriptor)
+	}
 
 	v.Logger.DEBUG("basic validators loaded")
 }